@@ -0,0 +1,131 @@
+package alpine_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/alpine"
+	"github.com/tbe/godom/types"
+)
+
+func TestAlpine(t *testing.T) {
+	suite.Run(t, new(AlpineTestSuite))
+}
+
+type AlpineTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AlpineTestSuite) testAttr(attribute types.Attribute, key, value string) {
+	attrs := make(map[string]string)
+	var flags []string
+	attribute(attrs, &flags, nil)
+
+	suite.Equal(map[string]string{key: value}, attrs)
+	suite.Empty(flags)
+}
+
+func (suite *AlpineTestSuite) testFlag(attribute types.Attribute, flag string) {
+	attrs := make(map[string]string)
+	var flags []string
+	attribute(attrs, &flags, nil)
+
+	suite.Empty(attrs)
+	suite.Equal([]string{flag}, flags)
+}
+
+func (suite *AlpineTestSuite) TestDirectives() {
+	suite.testAttr(alpine.DataExpr("{ open: false }"), "x-data", "{ open: false }")
+	suite.testAttr(alpine.Init("load()"), "x-init", "load()")
+	suite.testAttr(alpine.Show("open"), "x-show", "open")
+	suite.testAttr(alpine.Show("open", "important"), "x-show.important", "open")
+	suite.testAttr(alpine.Bind("class", "{ active: open }"), "x-bind:class", "{ active: open }")
+	suite.testAttr(alpine.On("click", "open = true"), "x-on:click", "open = true")
+	suite.testAttr(alpine.On("keyup", "submit()", "enter", "prevent"), "x-on:keyup.enter.prevent", "submit()")
+	suite.testAttr(alpine.Text("title"), "x-text", "title")
+	suite.testAttr(alpine.HTML("content"), "x-html", "content")
+	suite.testAttr(alpine.Model("search", "debounce"), "x-model.debounce", "search")
+	suite.testAttr(alpine.Modelable("value"), "x-modelable", "value")
+	suite.testAttr(alpine.For("item in items"), "x-for", "item in items")
+	suite.testAttr(alpine.Key("item.id"), "x-bind:key", "item.id")
+	suite.testAttr(alpine.If("open"), "x-if", "open")
+	suite.testAttr(alpine.Effect("console.log(count)"), "x-effect", "console.log(count)")
+	suite.testAttr(alpine.Ref("input"), "x-ref", "input")
+	suite.testAttr(alpine.Teleport("body"), "x-teleport", "body")
+	suite.testAttr(alpine.ID("tab", "panel"), "x-id", `["tab","panel"]`)
+}
+
+func (suite *AlpineTestSuite) TestFlags() {
+	suite.testFlag(alpine.Transition(), "x-transition")
+	suite.testFlag(alpine.Transition("opacity"), "x-transition.opacity")
+	suite.testFlag(alpine.Ignore(), "x-ignore")
+	suite.testFlag(alpine.Cloak(), "x-cloak")
+}
+
+func (suite *AlpineTestSuite) TestInvalidNames() {
+	suite.Panics(func() { alpine.On("click me", "") })
+	suite.Panics(func() { alpine.Bind("a=b", "") })
+	suite.Panics(func() { alpine.Show("open", `a"b`) })
+	suite.Panics(func() { alpine.Transition("scale 80") })
+}
+
+type base struct {
+	ID int `json:"id"`
+}
+
+type dropdown struct {
+	base
+	Open    bool              `json:"open"`
+	Label   string            `json:"label,omitempty"`
+	Items   []string          `json:"items"`
+	Meta    map[string]any    `json:"meta-data"`
+	Toggle  alpine.JS         `json:"toggle"`
+	Created time.Time         `json:"created"`
+	Skipped string            `json:"-"`
+	Other   *dropdown         `json:"other"`
+	Extra   map[string]string `json:",omitempty"`
+	private int
+}
+
+func (suite *AlpineTestSuite) TestMarshal() {
+	object, err := alpine.Marshal(dropdown{
+		base:    base{ID: 1},
+		Items:   []string{"a", `"b"`},
+		Meta:    map[string]any{"z": 1, "a": true},
+		Toggle:  "function() { this.open = !this.open }",
+		Created: time.Date(2023, 10, 13, 18, 30, 0, 0, time.UTC),
+		Skipped: "skipped",
+		private: 1,
+	})
+	suite.NoError(err)
+	suite.Equal(
+		`{id:1,open:false,items:["a","\"b\""],"meta-data":{a:true,z:1},toggle:function() { this.open = !this.open },created:"2023-10-13T18:30:00Z",other:null}`,
+		object,
+	)
+}
+
+func (suite *AlpineTestSuite) TestMarshalErrors() {
+	_, err := alpine.Marshal(map[string]any{"invalid": func() {}})
+	suite.Error(err)
+
+	_, err = alpine.Marshal(map[int]string{1: "a"})
+	suite.Error(err)
+
+	suite.Panics(func() { alpine.Data(make(chan int)) })
+}
+
+func (suite *AlpineTestSuite) TestRender() {
+	doc := Div(alpine.Data(map[string]any{"open": false, "label": "Menu"}))(
+		Button(alpine.On("click", "open = !open"), CustomAttr(":class", "{ active: open }"))(Content("Toggle")),
+	)
+
+	var buf bytes.Buffer
+	suite.NoError(doc.Render(&buf))
+	suite.Equal(
+		`<div x-data="{label:&#34;Menu&#34;,open:false}"><button :class="{ active: open }" x-on:click="open = !open">Toggle</button></div>`,
+		buf.String(),
+	)
+}
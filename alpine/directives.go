@@ -0,0 +1,141 @@
+/*
+Package alpine provides typed attributes for the directives of Alpine.js (https://alpinejs.dev).
+
+The data of a component can be provided as Go value, which is serialized into a JavaScript object:
+
+	type dropdown struct {
+		Open   bool      `json:"open"`
+		Toggle alpine.JS `json:"toggle"`
+	}
+
+	Div(alpine.Data(dropdown{Toggle: "function() { this.open = !this.open }"}))(
+		Button(alpine.On("click", "toggle()"))(Content("Toggle")),
+		Div(alpine.Show("open"), alpine.Cloak())(Content("Contents")),
+	)
+
+All directives are rendered in their long form (`x-on:click` instead of `@click`). The shorthand forms can be used
+with godom.CustomAttr.
+*/
+package alpine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// The Data directive declares a component and its data. The data is serialized with Marshal.
+// It panics if the data can not be serialized.
+func Data(data any) types.Attribute {
+	object, err := Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("failed to serialize x-data: %v", err))
+	}
+	return helpers.SingleAttribute("x-data", object)
+}
+
+// The DataExpr directive declares a component and its data using a raw JavaScript expression.
+func DataExpr(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-data", expression)
+}
+
+// The Init directive runs the expression when the component is initialized.
+func Init(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-init", expression)
+}
+
+// The Show directive toggles the visibility of the element based on the expression.
+func Show(expression string, modifiers ...string) types.Attribute {
+	return helpers.ValidatedAttribute(directive("x-show", modifiers), expression)
+}
+
+// The Bind directive sets the attribute to the result of the expression.
+func Bind(attribute, expression string) types.Attribute {
+	return helpers.ValidatedAttribute("x-bind:"+attribute, expression)
+}
+
+// The On directive runs the expression when the event is dispatched, e.g. On("click", "open = true", "prevent").
+func On(event, expression string, modifiers ...string) types.Attribute {
+	return helpers.ValidatedAttribute(directive("x-on:"+event, modifiers), expression)
+}
+
+// The Text directive sets the text content of the element to the result of the expression.
+func Text(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-text", expression)
+}
+
+// The HTML directive sets the inner HTML of the element to the result of the expression.
+func HTML(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-html", expression)
+}
+
+// The Model directive binds the value of an input element to the data property.
+func Model(property string, modifiers ...string) types.Attribute {
+	return helpers.ValidatedAttribute(directive("x-model", modifiers), property)
+}
+
+// The Modelable directive exposes the data property to an x-model of the parent.
+func Modelable(property string) types.Attribute {
+	return helpers.SingleAttribute("x-modelable", property)
+}
+
+// The For directive repeats the Template element for every item, e.g. For("item in items").
+func For(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-for", expression)
+}
+
+// The Key directive specifies the key of an element repeated by For.
+func Key(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-bind:key", expression)
+}
+
+// The If directive adds the contents of the Template element only if the expression is true.
+func If(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-if", expression)
+}
+
+// The Transition directive applies the default transition when the element is shown or hidden.
+func Transition(modifiers ...string) types.Attribute {
+	return helpers.ValidatedFlag(directive("x-transition", modifiers))
+}
+
+// The Effect directive runs the expression whenever one of its dependencies changes.
+func Effect(expression string) types.Attribute {
+	return helpers.SingleAttribute("x-effect", expression)
+}
+
+// The Ignore flag prevents Alpine from initializing the element and its children.
+func Ignore() types.Attribute {
+	return helpers.FlagAttribute("x-ignore")
+}
+
+// The Ref directive registers the element as $refs.name.
+func Ref(name string) types.Attribute {
+	return helpers.SingleAttribute("x-ref", name)
+}
+
+// The Cloak flag hides the element until Alpine is initialized (requires `[x-cloak] { display: none }`).
+func Cloak() types.Attribute {
+	return helpers.FlagAttribute("x-cloak")
+}
+
+// The Teleport directive moves the contents of the Template element to the element matched by the selector.
+func Teleport(selector string) types.Attribute {
+	return helpers.SingleAttribute("x-teleport", selector)
+}
+
+// The ID directive declares the names of the IDs generated with $id for the element and its children.
+func ID(names ...string) types.Attribute {
+	object, _ := Marshal(names)
+	return helpers.SingleAttribute("x-id", object)
+}
+
+// directive appends the modifiers to the directive name.
+func directive(name string, modifiers []string) string {
+	if len(modifiers) == 0 {
+		return name
+	}
+	return name + "." + strings.Join(modifiers, ".")
+}
@@ -0,0 +1,217 @@
+package alpine
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// JS is a raw JavaScript expression. It is embedded as is by Marshal, which allows to add methods or
+// computed values to the data of a component.
+type JS string
+
+// identifierPattern matches object keys that can be written without quotes.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+var (
+	jsType            = reflect.TypeOf(JS(""))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Marshal serializes v into a JavaScript object literal.
+//
+// Values are encoded like encoding/json does, including the handling of `json` struct tags, with two differences:
+// object keys that are valid identifiers are not quoted, and values of type JS are embedded as raw JavaScript.
+// Map keys are sorted for a stable result.
+func Marshal(v any) (string, error) {
+	var buf bytes.Buffer
+	if err := marshal(&buf, reflect.ValueOf(v)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func marshal(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if v.Type() == jsType {
+		buf.WriteString(v.String())
+		return nil
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return marshalJSON(buf, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return marshal(buf, v.Elem())
+	case reflect.Struct:
+		return marshalStruct(buf, v)
+	case reflect.Map:
+		return marshalMap(buf, v)
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// byte slices are encoded as base64 strings, like encoding/json does
+			return marshalJSON(buf, v)
+		}
+		return marshalArray(buf, v)
+	case reflect.Array:
+		return marshalArray(buf, v)
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fmt.Errorf("alpine: unsupported type %s", v.Type())
+	default:
+		return marshalJSON(buf, v)
+	}
+}
+
+// marshalJSON encodes v using encoding/json.
+func marshalJSON(buf *bytes.Buffer, v reflect.Value) error {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+func marshalKey(buf *bytes.Buffer, key string) {
+	if identifierPattern.MatchString(key) {
+		buf.WriteString(key)
+	} else {
+		data, _ := json.Marshal(key)
+		buf.Write(data)
+	}
+	buf.WriteByte(':')
+}
+
+func marshalArray(buf *bytes.Buffer, v reflect.Value) error {
+	buf.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := marshal(buf, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func marshalMap(buf *bytes.Buffer, v reflect.Value) error {
+	if v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("alpine: unsupported map key type %s", v.Type().Key())
+	}
+
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		marshalKey(buf, key.String())
+		if err := marshal(buf, v.MapIndex(key)); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func marshalStruct(buf *bytes.Buffer, v reflect.Value) error {
+	buf.WriteByte('{')
+	first := true
+	if err := marshalFields(buf, v, &first); err != nil {
+		return err
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// marshalFields writes the fields of the struct v. Embedded structs without a name tag are inlined.
+func marshalFields(buf *bytes.Buffer, v reflect.Value, first *bool) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv, ft = fv.Elem(), ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := marshalFields(buf, fv, first); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if strings.Contains(options, "omitempty") && isEmpty(fv) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if !*first {
+			buf.WriteByte(',')
+		}
+		*first = false
+		marshalKey(buf, name)
+		if err := marshal(buf, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEmpty reports if v is empty in the sense of the omitempty option of encoding/json.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
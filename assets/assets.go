@@ -0,0 +1,178 @@
+/*
+Package assets fingerprints static assets, so they can be cached forever by browsers.
+
+A Manifest scans an fs.FS and names every file after the hash of its content, e.g. "css/app.css" becomes
+"css/app.5d41402a.css". Elements refer to the assets by their original names, which are resolved to the
+fingerprinted URLs:
+
+	//go:embed static
+	var static embed.FS
+
+	files, _ := fs.Sub(static, "static")
+	manifest, err := assets.New(files, "/static/")
+	http.Handle("/static/", manifest.Handler())
+
+	Link(Rel("stylesheet"), manifest.AssetHRef("css/app.css"))
+	// <link href="/static/css/app.5d41402a.css" rel="stylesheet"/>
+
+As the URL changes with the content, the Handler serves the fingerprinted files with immutable cache headers.
+*/
+package assets
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/types"
+)
+
+// ImmutableCacheControl is the Cache-Control header of fingerprinted files.
+const ImmutableCacheControl = "public, max-age=31536000, immutable"
+
+// hashLength is the number of hex digits of the content hash in fingerprinted names.
+const hashLength = 8
+
+// Manifest maps the names of the files of an fs.FS to their fingerprinted names.
+// It is immutable after creation and safe for concurrent use.
+type Manifest struct {
+	fsys   fs.FS
+	prefix string
+	// files maps the original names to the fingerprinted names
+	files map[string]string
+	// originals maps the fingerprinted names to the original names
+	originals map[string]string
+	// hashes maps the original names to the hashes of their content
+	hashes map[string]string
+}
+
+// New scans all files of fsys and returns their Manifest. The URLs of the files are the prefix followed by
+// their fingerprinted name.
+func New(fsys fs.FS, prefix string) (*Manifest, error) {
+	m := &Manifest{
+		fsys:      fsys,
+		prefix:    prefix,
+		files:     make(map[string]string),
+		originals: make(map[string]string),
+		hashes:    make(map[string]string),
+	}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		hash := contentHash(data)
+		hashed := fingerprint(name, hash)
+		m.files[name] = hashed
+		m.originals[hashed] = name
+		m.hashes[name] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Fingerprint returns the fingerprinted name of a file with the given content.
+func Fingerprint(name string, data []byte) string {
+	return fingerprint(name, contentHash(data))
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
+
+func fingerprint(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// Path returns the fingerprinted name of the named file.
+func (m *Manifest) Path(name string) (string, error) {
+	hashed, exists := m.files[name]
+	if !exists {
+		return "", fmt.Errorf("assets: unknown file %s", name)
+	}
+	return hashed, nil
+}
+
+// URL returns the fingerprinted URL of the named file. It panics if the file is not part of the manifest.
+func (m *Manifest) URL(name string) string {
+	hashed, err := m.Path(name)
+	if err != nil {
+		panic(err)
+	}
+	return m.prefix + hashed
+}
+
+// AssetHRef returns the HRef attribute for the fingerprinted URL of the named file.
+// It panics if the file is not part of the manifest.
+func (m *Manifest) AssetHRef(name string) types.Attribute {
+	return godom.HRef(m.URL(name))
+}
+
+// AssetSrc returns the Src attribute for the fingerprinted URL of the named file.
+// It panics if the file is not part of the manifest.
+func (m *Manifest) AssetSrc(name string) types.Attribute {
+	return godom.Src(m.URL(name))
+}
+
+// Files returns a copy of the mapping of the original names to the fingerprinted names.
+func (m *Manifest) Files() map[string]string {
+	files := make(map[string]string, len(m.files))
+	for name, hashed := range m.files {
+		files[name] = hashed
+	}
+	return files
+}
+
+// MarshalJSON encodes the manifest as JSON object, mapping the original names to the fingerprinted names.
+func (m *Manifest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.files)
+}
+
+// Handler returns an http.Handler that serves the files below the URL prefix of the manifest.
+func (m *Manifest) Handler() http.Handler {
+	return http.StripPrefix(m.prefix, m)
+}
+
+// ServeHTTP serves the file named by the path of the request. Fingerprinted files are served with immutable cache
+// headers. Files requested by their original names are served as well, but must be revalidated by the client.
+func (m *Manifest) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+
+	cacheControl := ImmutableCacheControl
+	original, exists := m.originals[name]
+	if !exists {
+		if _, exists = m.files[name]; !exists {
+			http.NotFound(w, r)
+			return
+		}
+		original = name
+		cacheControl = "no-cache"
+	}
+
+	data, err := fs.ReadFile(m.fsys, original)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("ETag", `"`+m.hashes[original]+`"`)
+	http.ServeContent(w, r, original, time.Time{}, bytes.NewReader(data))
+}
@@ -0,0 +1,88 @@
+package assets_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/assets"
+)
+
+func TestAssets(t *testing.T) {
+	suite.Run(t, new(AssetsTestSuite))
+}
+
+type AssetsTestSuite struct {
+	suite.Suite
+	manifest *assets.Manifest
+}
+
+func (suite *AssetsTestSuite) SetupTest() {
+	var err error
+	suite.manifest, err = assets.New(fstest.MapFS{
+		"css/app.css": {Data: []byte("body{}")},
+		"js/app.js":   {Data: []byte("alert(1)")},
+		"LICENSE":     {Data: []byte("body{}")},
+	}, "/static/")
+	suite.Require().NoError(err)
+}
+
+func (suite *AssetsTestSuite) TestFingerprint() {
+	suite.Equal("css/app.7c98040a.css", assets.Fingerprint("css/app.css", []byte("body{}")))
+	suite.Equal("LICENSE.7c98040a", assets.Fingerprint("LICENSE", []byte("body{}")))
+}
+
+func (suite *AssetsTestSuite) TestManifest() {
+	suite.Equal(map[string]string{
+		"css/app.css": "css/app.7c98040a.css",
+		"js/app.js":   "js/app.6e11c72f.js",
+		"LICENSE":     "LICENSE.7c98040a",
+	}, suite.manifest.Files())
+
+	data, err := json.Marshal(suite.manifest)
+	suite.NoError(err)
+	suite.JSONEq(`{"css/app.css":"css/app.7c98040a.css","js/app.js":"js/app.6e11c72f.js","LICENSE":"LICENSE.7c98040a"}`, string(data))
+
+	_, err = suite.manifest.Path("missing.css")
+	suite.Error(err)
+	suite.Panics(func() { suite.manifest.AssetSrc("missing.js") })
+}
+
+func (suite *AssetsTestSuite) TestAttributes() {
+	var buf bytes.Buffer
+	suite.NoError(Div()(
+		Link(Rel("stylesheet"), suite.manifest.AssetHRef("css/app.css")),
+		Script(suite.manifest.AssetSrc("js/app.js"))(),
+	).Render(&buf))
+	suite.Equal(
+		`<div><link href="/static/css/app.7c98040a.css" rel="stylesheet"/><script src="/static/js/app.6e11c72f.js"></script></div>`,
+		buf.String(),
+	)
+}
+
+func (suite *AssetsTestSuite) TestHandler() {
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		suite.manifest.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := serve("/static/css/app.7c98040a.css")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("body{}", rec.Body.String())
+	suite.Equal(assets.ImmutableCacheControl, rec.Header().Get("Cache-Control"))
+	suite.Equal(`"7c98040a"`, rec.Header().Get("ETag"))
+	suite.Contains(rec.Header().Get("Content-Type"), "text/css")
+
+	rec = serve("/static/css/app.css")
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("no-cache", rec.Header().Get("Cache-Control"))
+
+	suite.Equal(http.StatusNotFound, serve("/static/css/app.00000000.css").Code)
+	suite.Equal(http.StatusNotFound, serve("/other/css/app.css").Code)
+}
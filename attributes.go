@@ -158,6 +158,20 @@ func Class(classes ...string) types.Attribute {
 	return helpers.MultiValueAttribute("class", classes...)
 }
 
+// The ClassIf attribute adds the classnames to an element, if the condition is true.
+//
+// This is a global types.Attribute.
+func ClassIf(condition bool, classes ...string) types.Attribute {
+	return helpers.ConditionalValues("class", condition, classes...)
+}
+
+// The ClassMap attribute adds all classnames mapped to true to an element, e.g. ClassMap(map[string]bool{"active": isActive}).
+//
+// This is a global types.Attribute.
+func ClassMap(classes map[string]bool) types.Attribute {
+	return helpers.ValueMap("class", classes)
+}
+
 // The Cols attribute specifies  the visible width of a TextArea
 //
 // This attribute is allowed for:
@@ -179,8 +193,8 @@ func ColSpan(columns int) types.Attribute {
 //
 // This attribute is allowed for:
 // - Meta
-func ContentAttr(charset string) types.Attribute {
-	return helpers.SingleAttribute("content", charset)
+func ContentAttr(content string) types.Attribute {
+	return helpers.SingleAttribute("content", content)
 }
 
 // The ContentEditable attribute specifies whether the content of an element is editable or not.
@@ -217,6 +231,23 @@ func CrossOrigin(coords string) types.Attribute {
 	return helpers.SingleAttribute("crossorigin", coords)
 }
 
+// The CustomAttr attribute specifies an attribute with an arbitrary name, like the directives of frontend frameworks
+// (e.g. `x-on:click`, `@click` or `:class`).
+// It panics if the name is not a valid HTML attribute name.
+//
+// This is a global types.Attribute.
+func CustomAttr(name, value string) types.Attribute {
+	return helpers.ValidatedAttribute(name, value)
+}
+
+// The CustomFlag flag specifies a flag with an arbitrary name (e.g. `x-cloak`).
+// It panics if the name is not a valid HTML attribute name.
+//
+// This is a global types.Attribute.
+func CustomFlag(name string) types.Attribute {
+	return helpers.ValidatedFlag(name)
+}
+
 // The Data_ attributes are used to store custom data private to the page or application.
 //
 // This is a global types.Attribute.
@@ -470,6 +501,7 @@ func ID(id string) types.Attribute {
 // The Integrity attribute allows a browser to check the fetched script to ensure that the code is never loaded if the source has been manipulated.
 //
 // This attribute is allowed for:
+// - Link
 // - Script
 func Integrity(hash string) types.Attribute {
 	return helpers.SingleAttribute("integrity", hash)
@@ -750,8 +782,16 @@ func ReferrerPolicy(policy string) types.Attribute {
 // - Area
 // - Form
 // - Link
-func Rel(relationship string) types.Attribute {
-	return helpers.SingleAttribute("rel", relationship)
+func Rel(relationships ...string) types.Attribute {
+	return helpers.MultiValueAttribute("rel", relationships...)
+}
+
+// The RemoveClass attribute removes the classnames from an element, e.g. classes added by a base component.
+// It only affects the classes added by the attributes before it.
+//
+// This is a global types.Attribute.
+func RemoveClass(classes ...string) types.Attribute {
+	return helpers.RemoveValues("class", classes...)
 }
 
 // The Required flag specifies that an Input field must be filled out before submitting the form.
@@ -927,10 +967,12 @@ func Step[T NumOrString](step T) types.Attribute {
 }
 
 // The StyleAttr attribute specifies an inline CSS style for an Element.
+// It can be combined with other StyleAttr and css.Style attributes: the declarations are merged, and declarations
+// of the same property replace the earlier ones.
 //
 // This is a global types.Attribute.
 func StyleAttr(style string) types.Attribute {
-	return helpers.SingleAttribute("style", style)
+	return helpers.StyleAttribute(style)
 }
 
 // The TabIndex attribute specifies the tabbing order of an Element.
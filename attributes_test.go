@@ -104,6 +104,28 @@ func (suite *AttributesTestSuite) TestMultipleClasses() {
 	suite.testAttr(Class("testB", "testC"), "class", "testA testB testC")
 }
 
+func (suite *AttributesTestSuite) TestDuplicateClasses() {
+	suite.testAttr(Class("testA", "testB"), "class", "testA testB")
+	suite.testAttr(Class("testB testC", "testA"), "class", "testA testB testC")
+}
+
+func (suite *AttributesTestSuite) TestClassIf() {
+	suite.testAttr(ClassIf(true, "testA"), "class", "testA")
+	suite.testAttr(ClassIf(false, "testB"), "class", "testA")
+}
+
+func (suite *AttributesTestSuite) TestClassMap() {
+	suite.testAttr(ClassMap(map[string]bool{"testC": true, "testB": false, "testA": true}), "class", "testA testC")
+	suite.testAttr(ClassMap(map[string]bool{"testB": false}), "class", "testA testC")
+}
+
+func (suite *AttributesTestSuite) TestRemoveClass() {
+	suite.testAttr(Class("testA", "testB", "testC"), "class", "testA testB testC")
+	suite.testAttr(RemoveClass("testB", "testD"), "class", "testA testC")
+	suite.testAttrAndFlag(RemoveClass("testA", "testC"), map[string]string{}, nil)
+	suite.testAttrAndFlag(RemoveClass("testA"), map[string]string{}, nil)
+}
+
 func (suite *AttributesTestSuite) TestCols() {
 	suite.testAttr(Cols(10), "cols", "10")
 }
@@ -140,6 +162,18 @@ func (suite *AttributesTestSuite) TestData() {
 	suite.testAttr(DataAttr("test"), "data", "test")
 }
 
+func (suite *AttributesTestSuite) TestCustomAttr() {
+	suite.testAttr(CustomAttr("x-on:click", "open = !open"), "x-on:click", "open = !open")
+}
+
+func (suite *AttributesTestSuite) TestCustomAttrInvalid() {
+	suite.Panics(func() { CustomAttr("on click", "") })
+}
+
+func (suite *AttributesTestSuite) TestCustomFlag() {
+	suite.testFlag(CustomFlag("x-cloak"), "x-cloak")
+}
+
 func (suite *AttributesTestSuite) TestData_() {
 	suite.testAttr(Data_("x", "test"), "data-x", "test")
 }
@@ -689,6 +723,7 @@ func (suite *AttributesTestSuite) TestReferrerPolicy() {
 
 func (suite *AttributesTestSuite) TestRel() {
 	suite.testAttr(Rel("test"), "rel", "test")
+	suite.testAttr(Rel("preload", "test"), "rel", "test preload")
 }
 
 func (suite *AttributesTestSuite) TestRequired() {
@@ -0,0 +1,111 @@
+/*
+Package cache renders subtrees once and reuses the rendered bytes, e.g. for navigation menus and footers that
+only change with a deploy:
+
+	cache.Cached("footer", 0, func() types.Element {
+		return Footer()(expensiveLinks()...)
+	}, "layout")
+
+The rendered bytes are stored in a Store, an in-memory LRU by default. They are kept until the ttl expires,
+the Store evicts them or they are invalidated, by their key with Invalidate or by one of their tags with
+InvalidateTag.
+
+Cached subtrees are rendered without the state of render.Document, so head entries, assets and scoped styles
+contributed by them are not collected. Head entries and assets fail the render with head.ErrDetached instead of
+being written into the body. Render such contributions outside of the cached subtree. Attribute hooks of
+the render, like the nonce of the csp package and the collection of scoped styles by css.Collect, are applied to
+cached subtrees on every render.
+*/
+package cache
+
+import (
+	"io"
+	"time"
+
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+// DefaultCapacity is the capacity of the LRU used by the package level functions.
+const DefaultCapacity = 1024
+
+// Cache renders subtrees into a Store.
+type Cache struct {
+	store Store
+}
+
+// New returns a Cache that stores the rendered subtrees in store.
+func New(store Store) *Cache {
+	return &Cache{store: store}
+}
+
+// defaultCache is the Cache used by the package level functions.
+var defaultCache = New(NewLRU(DefaultCapacity))
+
+// Cached returns an element that renders the element returned by creator once and writes the stored bytes
+// on subsequent renders, until the ttl expires or the key or one of the tags is invalidated. A ttl of 0 does
+// not expire. The key must identify the subtree, including all data it depends on, like the language of the page.
+//
+// The start tags of the elements that attribute hooks (see render.WithAttributeHook) apply to, like the Script,
+// Style and Link elements for the nonce of the csp package, are stored with open attributes, and the hooks of each
+// render are applied to them. If a render has hooks for other tags, the subtree is rendered and stored again.
+// If the render fails, nothing is stored.
+func (c *Cache) Cached(key string, ttl time.Duration, creator util.ElementCreator, tags ...string) types.Element {
+	return &cachedElement{cache: c, key: key, ttl: ttl, creator: creator, tags: tags}
+}
+
+// Invalidate removes the subtree stored for key, so it is rendered again by the next render.
+func (c *Cache) Invalidate(key string) {
+	c.store.Delete(key)
+}
+
+// InvalidateTag removes all subtrees stored with the tag.
+func (c *Cache) InvalidateTag(tag string) {
+	c.store.DeleteTag(tag)
+}
+
+// Cached returns an element that is cached in the default Cache. See Cache.Cached.
+func Cached(key string, ttl time.Duration, creator util.ElementCreator, tags ...string) types.Element {
+	return defaultCache.Cached(key, ttl, creator, tags...)
+}
+
+// Invalidate removes the subtree stored for key from the default Cache.
+func Invalidate(key string) {
+	defaultCache.Invalidate(key)
+}
+
+// InvalidateTag removes all subtrees stored with the tag from the default Cache.
+func InvalidateTag(tag string) {
+	defaultCache.InvalidateTag(tag)
+}
+
+type cachedElement struct {
+	cache   *Cache
+	key     string
+	ttl     time.Duration
+	creator util.ElementCreator
+	tags    []string
+}
+
+func (c *cachedElement) Render(writer io.Writer) error {
+	if value, ok := c.cache.store.Get(c.key); ok {
+		var snapshot helpers.Snapshot
+		// values that can not be decoded, e.g. written by an older version, are rendered again
+		if snapshot.UnmarshalBinary(value) == nil && snapshot.Covers(writer) {
+			return snapshot.Render(writer)
+		}
+	}
+
+	// the snapshot is detached from the document, as its slots would otherwise refer to the state of this render
+	snapshot, err := helpers.TakeSnapshot(writer, c.creator())
+	if err != nil {
+		return err
+	}
+	value, err := snapshot.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	c.cache.store.Set(c.key, value, c.ttl, c.tags)
+	return snapshot.Render(writer)
+}
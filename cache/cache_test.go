@@ -0,0 +1,226 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/cache"
+	"github.com/tbe/godom/csp"
+	"github.com/tbe/godom/head"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+}
+
+func TestCache(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+// counting returns a creator that renders the number of its calls.
+func counting(calls *int) util.ElementCreator {
+	return func() types.Element {
+		*calls++
+		return Nav(Class("menu"))(Content(string(rune('0' + *calls))))
+	}
+}
+
+func (s *CacheTestSuite) render(element types.Element) string {
+	var buf bytes.Buffer
+	s.NoError(element.Render(&buf))
+	return buf.String()
+}
+
+func (s *CacheTestSuite) TestCached() {
+	c := cache.New(cache.NewLRU(10))
+	calls := 0
+	element := c.Cached("menu", 0, counting(&calls))
+
+	s.Equal(`<nav class="menu">1</nav>`, s.render(element))
+	s.Equal(`<nav class="menu">1</nav>`, s.render(element))
+	s.Equal(`<nav class="menu">1</nav>`, s.render(c.Cached("menu", 0, counting(&calls))))
+	s.Equal(1, calls)
+}
+
+func (s *CacheTestSuite) TestInvalidate() {
+	c := cache.New(cache.NewLRU(10))
+	calls := 0
+	menu := c.Cached("menu", 0, counting(&calls), "layout")
+	footer := c.Cached("footer", 0, counting(&calls), "layout")
+	other := c.Cached("other", 0, counting(&calls))
+
+	s.Equal(`<nav class="menu">1</nav><nav class="menu">2</nav><nav class="menu">3</nav>`,
+		s.render(Group(menu, footer, other)))
+
+	c.Invalidate("menu")
+	s.Equal(`<nav class="menu">4</nav><nav class="menu">2</nav><nav class="menu">3</nav>`,
+		s.render(Group(menu, footer, other)))
+
+	c.InvalidateTag("layout")
+	s.Equal(`<nav class="menu">5</nav><nav class="menu">6</nav><nav class="menu">3</nav>`,
+		s.render(Group(menu, footer, other)))
+}
+
+func (s *CacheTestSuite) TestTTL() {
+	c := cache.New(cache.NewLRU(10))
+	calls := 0
+	element := c.Cached("menu", 10*time.Millisecond, counting(&calls))
+
+	s.Equal(`<nav class="menu">1</nav>`, s.render(element))
+	s.Equal(`<nav class="menu">1</nav>`, s.render(element))
+	time.Sleep(20 * time.Millisecond)
+	s.Equal(`<nav class="menu">2</nav>`, s.render(element))
+}
+
+func (s *CacheTestSuite) TestEviction() {
+	lru := cache.NewLRU(2)
+	lru.Set("a", []byte("a"), 0, []string{"tag"})
+	lru.Set("b", []byte("b"), 0, nil)
+	_, ok := lru.Get("a")
+	s.True(ok)
+
+	lru.Set("c", []byte("c"), 0, nil)
+	s.Equal(2, lru.Len())
+	_, ok = lru.Get("b")
+	s.False(ok, "the least recently used value is evicted")
+	value, ok := lru.Get("a")
+	s.True(ok)
+	s.Equal("a", string(value))
+
+	lru.DeleteTag("tag")
+	_, ok = lru.Get("a")
+	s.False(ok)
+	s.Equal(1, lru.Len())
+}
+
+func (s *CacheTestSuite) TestFailedRender() {
+	c := cache.New(cache.NewLRU(10))
+	failing := true
+	element := c.Cached("menu", 0, func() types.Element {
+		if failing {
+			return Div()(render.Slot(func(io.Writer) error { return context.Canceled }))
+		}
+		return Div()()
+	})
+
+	var buf bytes.Buffer
+	s.ErrorIs(element.Render(&buf), context.Canceled)
+	failing = false
+	s.Equal("<div></div>", s.render(element))
+}
+
+func (s *CacheTestSuite) TestAttributeHooks() {
+	c := cache.New(cache.NewLRU(10))
+	calls := 0
+	element := c.Cached("menu", 0, counting(&calls))
+	s.Equal(`<nav class="menu">1</nav>`, s.render(element))
+
+	// hooks for tags that are not stored with open attributes render the subtree again
+	ctx := render.WithAttributeHook(context.Background(), func(_ string, attrs map[string]string, _ *[]string) error {
+		attrs["nonce"] = "abc"
+		return nil
+	})
+	var buf bytes.Buffer
+	s.NoError(element.Render(render.WithContext(&buf, ctx)))
+	s.Equal(`<nav class="menu" nonce="abc">2</nav>`, buf.String())
+
+	buf.Reset()
+	s.NoError(element.Render(render.WithContext(&buf, ctx)))
+	s.Equal(`<nav class="menu" nonce="abc">2</nav>`, buf.String())
+	s.Equal(`<nav class="menu">2</nav>`, s.render(element))
+	s.Equal(2, calls)
+}
+
+func (s *CacheTestSuite) TestNonce() {
+	c := cache.New(cache.NewLRU(10))
+	calls := 0
+	element := c.Cached("scripts", 0, func() types.Element {
+		calls++
+		return Div(Class("scripts"))(
+			Script(Src("app.js"))(),
+			Link(Rel("stylesheet"), HRef("app.css")),
+			Content(string(rune('0'+calls))),
+		)
+	})
+
+	for _, nonce := range []string{"first", "second"} {
+		var buf bytes.Buffer
+		s.NoError(element.Render(render.WithContext(&buf, csp.WithNonce(context.Background(), nonce))))
+		s.Equal(`<div class="scripts"><script nonce="`+nonce+`" src="app.js"></script>`+
+			`<link href="app.css" rel="stylesheet"/>1</div>`, buf.String())
+	}
+	s.Equal(`<div class="scripts"><script src="app.js"></script><link href="app.css" rel="stylesheet"/>1</div>`,
+		s.render(element))
+	s.Equal(1, calls, "the subtree is rendered once")
+}
+
+func (s *CacheTestSuite) TestNested() {
+	c := cache.New(cache.NewLRU(10))
+	inner := c.Cached("inner", 0, func() types.Element { return Script(Src("inner.js"))() })
+	outer := c.Cached("outer", 0, func() types.Element { return Div()(inner, Script(Src("outer.js"))()) })
+
+	s.Equal(`<div><script src="inner.js"></script><script src="outer.js"></script></div>`, s.render(outer))
+	for _, nonce := range []string{"first", "second"} {
+		var buf bytes.Buffer
+		s.NoError(outer.Render(render.WithContext(&buf, csp.WithNonce(context.Background(), nonce))))
+		s.Equal(`<div><script nonce="`+nonce+`" src="inner.js"></script><script nonce="`+nonce+`" src="outer.js">`+
+			`</script></div>`, buf.String())
+	}
+}
+
+func (s *CacheTestSuite) TestAttributeOrder() {
+	c := cache.New(cache.NewLRU(10))
+	element := c.Cached("ordered", 0, func() types.Element {
+		return Div(ID("i"), Class("c"), Data_("a", "b"))()
+	})
+
+	var buf bytes.Buffer
+	ordered := helpers.WithAttributeOrder(context.Background(), helpers.CanonicalOrder)
+	s.NoError(element.Render(render.WithContext(&buf, ordered)))
+	s.Equal(`<div id="i" class="c" data-a="b"></div>`, buf.String())
+
+	// renders with another order do not use the stored subtree
+	s.Equal(`<div class="c" data-a="b" id="i"></div>`, s.render(element))
+}
+
+func (s *CacheTestSuite) TestHeadEntries() {
+	c := cache.New(cache.NewLRU(10))
+	element := c.Cached("title", 0, func() types.Element {
+		return Footer()(head.Title("x"))
+	})
+
+	var buf bytes.Buffer
+	s.ErrorIs(render.Document(&buf, HTML()(head.Head()(), Body()(element))), head.ErrDetached)
+}
+
+func (s *CacheTestSuite) TestInvalidValue() {
+	lru := cache.NewLRU(10)
+	lru.Set("menu", []byte(`<nav>stale</nav>`), 0, nil)
+	calls := 0
+	s.Equal(`<nav class="menu">1</nav>`, s.render(cache.New(lru).Cached("menu", 0, counting(&calls))))
+}
+
+func (s *CacheTestSuite) TestDocument() {
+	c := cache.New(cache.NewLRU(10))
+	element := c.Cached("slot", 0, func() types.Element {
+		return Div()(render.Slot(func(w io.Writer) error {
+			_, err := w.Write([]byte("filled"))
+			return err
+		}))
+	})
+
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		s.NoError(render.Document(&buf, Group(Span()(), element)))
+		s.Equal("<span></span><div>filled</div>", buf.String())
+	}
+}
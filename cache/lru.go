@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Store stores rendered subtrees. Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the value stored for key, if it exists and has not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores the value for key. A ttl of 0 stores the value until it is evicted or invalidated.
+	// The tags allow to invalidate the value together with other values.
+	Set(key string, value []byte, ttl time.Duration, tags []string)
+	// Delete removes the value stored for key.
+	Delete(key string)
+	// DeleteTag removes all values stored with the tag.
+	DeleteTag(tag string)
+}
+
+// LRU is an in-memory Store that evicts the least recently used value when it is full.
+type LRU struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	tags    map[string]map[string]struct{}
+	recent  *list.List
+}
+
+// lruEntry is a value of an LRU.
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+	tags    []string
+}
+
+// NewLRU returns an LRU that stores up to capacity values. A capacity of 0 or less does not limit the number
+// of values.
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		tags:     make(map[string]map[string]struct{}),
+		recent:   list.New(),
+	}
+}
+
+// Get implements Store.
+func (l *LRU) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		l.remove(element)
+		return nil, false
+	}
+	l.recent.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set implements Store.
+func (l *LRU) Set(key string, value []byte, ttl time.Duration, tags []string) {
+	entry := &lruEntry{key: key, value: value, tags: tags}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+	l.entries[key] = l.recent.PushFront(entry)
+	for _, tag := range tags {
+		if l.tags[tag] == nil {
+			l.tags[tag] = make(map[string]struct{})
+		}
+		l.tags[tag][key] = struct{}{}
+	}
+
+	if l.capacity > 0 && l.recent.Len() > l.capacity {
+		l.remove(l.recent.Back())
+	}
+}
+
+// Delete implements Store.
+func (l *LRU) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+}
+
+// DeleteTag implements Store.
+func (l *LRU) DeleteTag(tag string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key := range l.tags[tag] {
+		l.remove(l.entries[key])
+	}
+}
+
+// Len returns the number of stored values, including expired values that were not removed yet.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.recent.Len()
+}
+
+// remove removes the element and its tags. l.mu must be held.
+func (l *LRU) remove(element *list.Element) {
+	entry := l.recent.Remove(element).(*lruEntry)
+	delete(l.entries, entry.key)
+	for _, tag := range entry.tags {
+		delete(l.tags[tag], entry.key)
+		if len(l.tags[tag]) == 0 {
+			delete(l.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Package component provides reusable components with typed props, children and named slots.
+
+A component is a function of its props and its Children. The Children hold the default children, the named slots
+and the attributes passed by the caller, which are usually forwarded to the root element of the component:
+
+	type CardProps struct {
+		Title string
+	}
+
+	var Card = component.New(func(props CardProps, c component.Children) types.Element {
+		return Div(c.Attrs(Class("card"))...)(
+			Header()(H2()(Content(props.Title)), c.Slot("header")),
+			Div(Class("card-body"))(c.Default()),
+			util.IfElem(c.Has("footer"), Footer()(c.Slot("footer"))),
+		)
+	})
+
+	Card(CardProps{Title: "Hello"}, Class("wide"))(
+		component.Slot("footer", A(HRef("/more"))(Content("More"))),
+		P()(Content("The body of the card.")),
+	)
+	// <div class="card wide"><header><h2>Hello</h2></header><div class="card-body"><p>The body of the card.</p></div>
+	// <footer><a href="/more">More</a></footer></div>
+*/
+package component
+
+import (
+	"io"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/types"
+)
+
+// Constructor creates an instance of a component with the given props and forwarded attributes.
+// The returned factory receives the children of the component.
+type Constructor[P any] func(props P, attrs ...types.Attribute) types.ElementFactory
+
+// New returns the Constructor of a component that is rendered by the given function.
+// The function is called with the props and children of every instance, when the children are passed.
+func New[P any](render func(props P, c Children) types.Element) Constructor[P] {
+	return func(props P, attrs ...types.Attribute) types.ElementFactory {
+		return func(children ...types.Element) types.Element {
+			c := Children{attrs: attrs}
+			for _, child := range children {
+				if slot, ok := child.(*slotElement); ok {
+					if c.slots == nil {
+						c.slots = make(map[string][]types.Element)
+					}
+					c.slots[slot.name] = append(c.slots[slot.name], slot.children...)
+				} else {
+					c.children = append(c.children, child)
+				}
+			}
+			return render(props, c)
+		}
+	}
+}
+
+// Children holds the children and the forwarded attributes of a component instance.
+type Children struct {
+	attrs    []types.Attribute
+	children []types.Element
+	slots    map[string][]types.Element
+}
+
+// Default returns all children that are not part of a named slot.
+func (c Children) Default() types.Element {
+	return godom.Group(c.children...)
+}
+
+// HasDefault reports whether there are children that are not part of a named slot.
+func (c Children) HasDefault() bool {
+	return len(c.children) > 0
+}
+
+// Slot returns the children of the named slot.
+func (c Children) Slot(name string) types.Element {
+	return godom.Group(c.slots[name]...)
+}
+
+// Has reports whether the named slot has children.
+func (c Children) Has(name string) bool {
+	return len(c.slots[name]) > 0
+}
+
+// Attrs returns the given attributes of the root element, followed by the attributes passed by the caller.
+// Classes are merged, so the caller can add classes to the ones of the component. To replace other attributes
+// of the component, the caller can use godom.Override.
+func (c Children) Attrs(attrs ...types.Attribute) []types.Attribute {
+	return append(attrs[:len(attrs):len(attrs)], c.attrs...)
+}
+
+// Slot returns the children for the named slot of a component. Outside of a component, the children are rendered
+// in place.
+func Slot(name string, children ...types.Element) types.Element {
+	return &slotElement{name: name, children: children}
+}
+
+type slotElement struct {
+	name     string
+	children []types.Element
+}
+
+func (s *slotElement) Render(writer io.Writer) error {
+	for _, child := range s.children {
+		if err := child.Render(writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
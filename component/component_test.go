@@ -0,0 +1,80 @@
+package component_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/component"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+func TestComponent(t *testing.T) {
+	suite.Run(t, new(ComponentTestSuite))
+}
+
+type ComponentTestSuite struct {
+	suite.Suite
+}
+
+type cardProps struct {
+	Title string
+}
+
+var card = component.New(func(props cardProps, c component.Children) types.Element {
+	return Div(c.Attrs(Class("card"), Type("card"))...)(
+		Header()(H2()(Content(props.Title)), c.Slot("header")),
+		util.IfElem(c.HasDefault(), Div(Class("card-body"))(c.Default())),
+		util.IfElem(c.Has("footer"), Footer()(c.Slot("footer"))),
+	)
+})
+
+func (suite *ComponentTestSuite) render(element types.Element) string {
+	var buf bytes.Buffer
+	suite.NoError(element.Render(&buf))
+	return buf.String()
+}
+
+func (suite *ComponentTestSuite) TestSlots() {
+	suite.Equal(
+		`<div class="card" type="card"><header><h2>Title</h2><small>Header</small></header>`+
+			`<div class="card-body"><p>First</p><p>Second</p></div><footer><a>One</a><a>Two</a></footer></div>`,
+		suite.render(card(cardProps{Title: "Title"})(
+			P()(Content("First")),
+			component.Slot("footer", A()(Content("One"))),
+			component.Slot("header", Small()(Content("Header"))),
+			P()(Content("Second")),
+			component.Slot("footer", A()(Content("Two"))),
+		)),
+	)
+}
+
+func (suite *ComponentTestSuite) TestEmpty() {
+	suite.Equal(
+		`<div class="card" type="card"><header><h2>Title</h2></header></div>`,
+		suite.render(card(cardProps{Title: "Title"})()),
+	)
+}
+
+func (suite *ComponentTestSuite) TestAttributeForwarding() {
+	suite.Equal(
+		`<div class="card wide" id="main" type="panel"><header><h2>Title</h2></header></div>`,
+		suite.render(card(cardProps{Title: "Title"}, Class("wide", "card"), ID("main"), Override("type", "panel"))()),
+	)
+}
+
+func (suite *ComponentTestSuite) TestNested() {
+	suite.Equal(
+		`<div class="card" type="card"><header><h2>Outer</h2></header><div class="card-body">`+
+			`<div class="card" type="card"><header><h2>Inner</h2></header><footer>Inner footer</footer></div></div></div>`,
+		suite.render(card(cardProps{Title: "Outer"})(
+			card(cardProps{Title: "Inner"})(component.Slot("footer", Content("Inner footer"))),
+		)),
+	)
+}
+
+func (suite *ComponentTestSuite) TestSlotOutsideComponent() {
+	suite.Equal(`<div><p>Slot</p></div>`, suite.render(Div()(component.Slot("header", P()(Content("Slot"))))))
+}
@@ -0,0 +1,188 @@
+/*
+Package csp adds Content-Security-Policy support to the rendering of documents.
+
+A nonce is generated for every request, announced in the Content-Security-Policy header, and attached to every
+Script, Style and preloading Link element during the render. This allows inline scripts and styles without
+'unsafe-inline':
+
+	handler := csp.Middleware(csp.StrictPolicy(), true)(httpdom.HandlerFunc(page))
+
+In strict mode, rendering fails if inline event handler attributes (like OnClick) are used, as these are blocked
+by a strict policy anyway.
+
+The nonce and strict mode are applied with render.AttributeHook, so they only affect elements built with the helpers
+package. Elements pre-rendered by the template package are not affected.
+*/
+package csp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// HeaderName is the name of the Content-Security-Policy header.
+const HeaderName = "Content-Security-Policy"
+
+// ErrInlineEventHandler is returned by renders in strict mode, if an inline event handler attribute is used.
+var ErrInlineEventHandler = errors.New("csp: inline event handler")
+
+// Tags holds the tags of the elements the nonce may be attached to. Pass them to helpers.Compile to keep the
+// start tags of these elements dynamic, so compiled elements can be rendered with a nonce.
+var Tags = []string{"script", "style", "link"}
+
+// NewNonce returns a new random nonce.
+func NewNonce() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b[:]), nil
+}
+
+// nonceKey is the context key for the nonce.
+type nonceKey struct{}
+
+// WithNonce returns a copy of ctx that attaches the nonce to every Script, Style and preloading Link element
+// rendered with it. Elements that already have a nonce keep it.
+func WithNonce(ctx context.Context, nonce string) context.Context {
+	ctx = context.WithValue(ctx, nonceKey{}, nonce)
+	return render.WithAttributeHook(ctx, func(tag string, attrs map[string]string, _ *[]string) error {
+		if _, exists := attrs["nonce"]; !exists && needsNonce(tag, attrs) {
+			attrs["nonce"] = nonce
+		}
+		return nil
+	}, Tags...)
+}
+
+// NonceFromContext returns the nonce of ctx, if it has one.
+func NonceFromContext(ctx context.Context) string {
+	nonce, _ := ctx.Value(nonceKey{}).(string)
+	return nonce
+}
+
+// needsNonce reports if an element requires a nonce to be loaded under the policy.
+func needsNonce(tag string, attrs map[string]string) bool {
+	switch tag {
+	case "script", "style":
+		return true
+	case "link":
+		for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+			if rel == "preload" || rel == "modulepreload" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithStrict returns a copy of ctx that fails the render of elements with inline event handler attributes.
+func WithStrict(ctx context.Context) context.Context {
+	return render.WithAttributeHook(ctx, func(tag string, attrs map[string]string, _ *[]string) error {
+		for key := range attrs {
+			if strings.HasPrefix(strings.ToLower(key), "on") {
+				return fmt.Errorf("%w: %s on <%s>", ErrInlineEventHandler, key, tag)
+			}
+		}
+		return nil
+	})
+}
+
+// Nonce returns an element that renders the element with WithNonce.
+func Nonce(nonce string, element types.Element) types.Element {
+	return &contextElement{element: element, apply: func(ctx context.Context) context.Context {
+		return WithNonce(ctx, nonce)
+	}}
+}
+
+// Strict returns an element that renders the element with WithStrict.
+func Strict(element types.Element) types.Element {
+	return &contextElement{element: element, apply: WithStrict}
+}
+
+// contextElement renders an element with a modified render context.
+type contextElement struct {
+	element types.Element
+	apply   func(ctx context.Context) context.Context
+}
+
+func (c *contextElement) Render(writer io.Writer) error {
+	return c.element.Render(render.WithContext(writer, c.apply(render.Context(writer))))
+}
+
+// Policy is a Content-Security-Policy, mapping the directives to their sources.
+type Policy map[string][]string
+
+// StrictPolicy returns a strict policy, that only allows scripts and styles with the nonce of the request.
+// Scripts loaded by these may load further scripts.
+func StrictPolicy() Policy {
+	return Policy{
+		"default-src": {"'self'"},
+		"script-src":  {"'strict-dynamic'"},
+		"style-src":   {"'self'"},
+		"object-src":  {"'none'"},
+		"base-uri":    {"'none'"},
+	}
+}
+
+// Header returns the value of the Content-Security-Policy header that allows the nonce for scripts and styles.
+// If the policy has no script-src or style-src directive, the sources of default-src are used for them.
+// The directives are sorted for a stable result.
+func (p Policy) Header(nonce string) string {
+	directives := make(map[string][]string, len(p)+2)
+	for name, sources := range p {
+		directives[name] = sources
+	}
+	if nonce != "" {
+		for _, name := range []string{"script-src", "style-src"} {
+			sources, exists := directives[name]
+			if !exists {
+				sources = directives["default-src"]
+			}
+			directives[name] = append(slices.Clip(sources), "'nonce-"+nonce+"'")
+		}
+	}
+
+	names := make([]string, 0, len(directives))
+	for name := range directives {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, strings.Join(append([]string{name}, directives[name]...), " "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Middleware returns a middleware that generates a nonce for every request, sets the Content-Security-Policy header,
+// and adds the nonce to the context of the request. Documents rendered with the request context, like those of the
+// httpdom package, get the nonce attached automatically. If strict is set, the context is also set up with WithStrict.
+func Middleware(policy Policy, strict bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nonce, err := NewNonce()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			ctx := WithNonce(r.Context(), nonce)
+			if strict {
+				ctx = WithStrict(ctx)
+			}
+			w.Header().Set(HeaderName, policy.Header(nonce))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
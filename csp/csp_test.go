@@ -0,0 +1,100 @@
+package csp_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/csp"
+	"github.com/tbe/godom/httpdom"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+func TestCSP(t *testing.T) {
+	suite.Run(t, new(CSPTestSuite))
+}
+
+type CSPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CSPTestSuite) TestNewNonce() {
+	first, err := csp.NewNonce()
+	suite.NoError(err)
+	second, err := csp.NewNonce()
+	suite.NoError(err)
+
+	suite.Len(first, 24)
+	suite.NotEqual(first, second)
+}
+
+func (suite *CSPTestSuite) TestNonce() {
+	doc := csp.Nonce("abc", Div()(
+		Script(Src("/app.js"))(),
+		Script(CustomAttr("nonce", "own"))(),
+		Style()(),
+		Link(Rel("preload"), HRef("/font.woff2")),
+		Link(Rel("stylesheet"), HRef("/main.css")),
+		Img(Src("/img.png")),
+	))
+
+	var buf bytes.Buffer
+	suite.NoError(doc.Render(&buf))
+	suite.Equal(
+		`<div><script nonce="abc" src="/app.js"></script><script nonce="own"></script><style nonce="abc"></style>`+
+			`<link href="/font.woff2" nonce="abc" rel="preload"/><link href="/main.css" rel="stylesheet"/><img src="/img.png"/></div>`,
+		buf.String(),
+	)
+}
+
+func (suite *CSPTestSuite) TestStrict() {
+	var buf bytes.Buffer
+	suite.NoError(csp.Strict(Button(Type("button"))(Content("OK"))).Render(&buf))
+
+	err := csp.Strict(Div()(Button(OnClick("alert(1)"))())).Render(&buf)
+	suite.ErrorIs(err, csp.ErrInlineEventHandler)
+	suite.ErrorContains(err, "onclick on <button>")
+}
+
+func (suite *CSPTestSuite) TestHeader() {
+	suite.Equal(
+		"base-uri 'none'; default-src 'self'; object-src 'none'; script-src 'strict-dynamic' 'nonce-abc'; style-src 'self' 'nonce-abc'",
+		csp.StrictPolicy().Header("abc"),
+	)
+	suite.Equal(
+		"default-src 'self'; script-src 'self' 'nonce-abc'; style-src 'self' 'nonce-abc'",
+		csp.Policy{"default-src": {"'self'"}}.Header("abc"),
+	)
+	suite.Equal("default-src 'self'", csp.Policy{"default-src": {"'self'"}}.Header(""))
+}
+
+func (suite *CSPTestSuite) TestMiddleware() {
+	var nonce string
+	page := func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		nonce = csp.NonceFromContext(r.Context())
+		return Script()(), nil
+	}
+
+	rec := httptest.NewRecorder()
+	csp.Middleware(csp.StrictPolicy(), true)(httpdom.HandlerFunc(page)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.NotEmpty(nonce)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal(csp.StrictPolicy().Header(nonce), rec.Header().Get(csp.HeaderName))
+	suite.Equal(`<script nonce="`+nonce+`"></script>`, rec.Body.String())
+}
+
+func (suite *CSPTestSuite) TestContext() {
+	ctx := csp.WithStrict(csp.WithNonce(context.Background(), "abc"))
+	suite.Equal("abc", csp.NonceFromContext(ctx))
+
+	var buf bytes.Buffer
+	suite.NoError(Style()().Render(render.WithContext(&buf, ctx)))
+	suite.Equal(`<style nonce="abc"></style>`, buf.String())
+	suite.ErrorIs(Div(OnLoad("init()"))().Render(render.WithContext(&buf, ctx)), csp.ErrInlineEventHandler)
+}
@@ -0,0 +1,96 @@
+package css_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/css"
+	"github.com/tbe/godom/types"
+)
+
+func TestCSS(t *testing.T) {
+	suite.Run(t, new(CSSTestSuite))
+}
+
+type CSSTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CSSTestSuite) style(attrs ...types.Attribute) string {
+	values := make(map[string]string)
+	var flags []string
+	for _, attr := range attrs {
+		attr(values, &flags, nil)
+	}
+	return values["style"]
+}
+
+func (suite *CSSTestSuite) TestValues() {
+	suite.Equal(css.Value("4px"), css.Px(4))
+	suite.Equal(css.Value("1.5rem"), css.Rem(1.5))
+	suite.Equal(css.Value("50%"), css.Percent(50))
+	suite.Equal(css.Value("0.5"), css.Num(0.5))
+	suite.Equal(css.Value("rgb(255 0 0 / 0.5)"), css.RGBA(255, 0, 0, 0.5))
+	suite.Equal(css.Value("#ff0000"), css.Hex("#ff0000;"))
+	suite.Equal(css.Value("var(--gap, 4px)"), css.Var("--gap", css.Px(4)))
+	suite.Equal(css.Value("calc(100% - 20px)"), css.Calc(css.Percent(100), "-", css.Px(20)))
+}
+
+func (suite *CSSTestSuite) TestEscaping() {
+	suite.Equal(css.Value(`"a\"b\\c\a "`), css.String("a\"b\\c\n"))
+	suite.Equal(css.Value(`url("/img.png\");x:y")`), css.URL(`/img.png");x:y`))
+	suite.Equal(css.Value(`my\;anim\ ation`), css.Keyword("my;anim ation"))
+	suite.Equal(css.Value(`\31 st`), css.Keyword("1st"))
+	suite.Equal("a\\:b:1", css.Property("a:b", "1").String())
+}
+
+func (suite *CSSTestSuite) TestStyle() {
+	suite.Equal(
+		"display:flex;margin:4px auto;--gap:1rem;gap:var(--gap)",
+		suite.style(css.Style(
+			css.Display(css.Flex),
+			css.Margin(css.Px(4), css.Auto),
+			css.Custom("gap", css.Rem(1)),
+			css.Gap(css.Var("--gap")),
+		)),
+	)
+}
+
+func (suite *CSSTestSuite) TestMerge() {
+	suite.Equal(
+		"color:red;background:none;margin:0px;margin-top:2px",
+		suite.style(
+			StyleAttr("margin-top: 1px; background: url('a;b.png')"),
+			css.Style(css.Color("red"), css.Background(css.None)),
+			css.Style(css.Margin(css.Px(0)), css.MarginTop(css.Px(2))),
+			css.Style(),
+		),
+	)
+}
+
+func (suite *CSSTestSuite) TestMergeStyleAttr() {
+	suite.Equal(
+		"margin:0px;color:blue;padding:1px",
+		suite.style(
+			css.Style(css.Color("red"), css.Margin(css.Px(0))),
+			StyleAttr("color: blue"),
+			StyleAttr("padding: 1px"),
+		),
+	)
+}
+
+func (suite *CSSTestSuite) TestParse() {
+	suite.Equal([]css.Declaration{
+		{Property: "background", Value: `url("a;b.png")`},
+		{Property: "content", Value: `"x:y"`},
+		{Property: "--empty-ok", Value: "1"},
+	}, css.Parse(`background: url("a;b.png"); content:"x:y";;invalid; --empty-ok : 1 ;`))
+}
+
+func (suite *CSSTestSuite) TestRender() {
+	var buf bytes.Buffer
+	suite.NoError(Div(css.Style(css.Content(css.String(`"><script>`))))().Render(&buf))
+	suite.Equal(`<div style="content:&#34;\&#34;&gt;\3c script&gt;&#34;"></div>`, buf.String())
+}
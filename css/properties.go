@@ -0,0 +1,281 @@
+package css
+
+// Display declares the display type of the element.
+func Display(value Value) Declaration {
+	return Property("display", value)
+}
+
+// Position declares how the element is positioned.
+func Position(value Value) Declaration {
+	return Property("position", value)
+}
+
+// Top declares the top offset of a positioned element.
+func Top(value Value) Declaration {
+	return Property("top", value)
+}
+
+// Right declares the right offset of a positioned element.
+func Right(value Value) Declaration {
+	return Property("right", value)
+}
+
+// Bottom declares the bottom offset of a positioned element.
+func Bottom(value Value) Declaration {
+	return Property("bottom", value)
+}
+
+// Left declares the left offset of a positioned element.
+func Left(value Value) Declaration {
+	return Property("left", value)
+}
+
+// Inset declares the offsets of a positioned element.
+func Inset(values ...Value) Declaration {
+	return Property("inset", values...)
+}
+
+// ZIndex declares the stack order of a positioned element.
+func ZIndex(value Value) Declaration {
+	return Property("z-index", value)
+}
+
+// Width declares the width of the element.
+func Width(value Value) Declaration {
+	return Property("width", value)
+}
+
+// Height declares the height of the element.
+func Height(value Value) Declaration {
+	return Property("height", value)
+}
+
+// MinWidth declares the minimum width of the element.
+func MinWidth(value Value) Declaration {
+	return Property("min-width", value)
+}
+
+// MinHeight declares the minimum height of the element.
+func MinHeight(value Value) Declaration {
+	return Property("min-height", value)
+}
+
+// MaxWidth declares the maximum width of the element.
+func MaxWidth(value Value) Declaration {
+	return Property("max-width", value)
+}
+
+// MaxHeight declares the maximum height of the element.
+func MaxHeight(value Value) Declaration {
+	return Property("max-height", value)
+}
+
+// Margin declares the margins of the element, e.g. Margin(Px(4), Auto).
+func Margin(values ...Value) Declaration {
+	return Property("margin", values...)
+}
+
+// MarginTop declares the top margin of the element.
+func MarginTop(value Value) Declaration {
+	return Property("margin-top", value)
+}
+
+// MarginRight declares the right margin of the element.
+func MarginRight(value Value) Declaration {
+	return Property("margin-right", value)
+}
+
+// MarginBottom declares the bottom margin of the element.
+func MarginBottom(value Value) Declaration {
+	return Property("margin-bottom", value)
+}
+
+// MarginLeft declares the left margin of the element.
+func MarginLeft(value Value) Declaration {
+	return Property("margin-left", value)
+}
+
+// Padding declares the paddings of the element, e.g. Padding(Px(4), Px(8)).
+func Padding(values ...Value) Declaration {
+	return Property("padding", values...)
+}
+
+// PaddingTop declares the top padding of the element.
+func PaddingTop(value Value) Declaration {
+	return Property("padding-top", value)
+}
+
+// PaddingRight declares the right padding of the element.
+func PaddingRight(value Value) Declaration {
+	return Property("padding-right", value)
+}
+
+// PaddingBottom declares the bottom padding of the element.
+func PaddingBottom(value Value) Declaration {
+	return Property("padding-bottom", value)
+}
+
+// PaddingLeft declares the left padding of the element.
+func PaddingLeft(value Value) Declaration {
+	return Property("padding-left", value)
+}
+
+// Border declares the width, style and color of the border, e.g. Border(Px(1), Keyword("solid"), Hex("ccc")).
+func Border(values ...Value) Declaration {
+	return Property("border", values...)
+}
+
+// BorderRadius declares the radius of the corners of the border.
+func BorderRadius(values ...Value) Declaration {
+	return Property("border-radius", values...)
+}
+
+// BoxSizing declares how the size of the element is calculated.
+func BoxSizing(value Value) Declaration {
+	return Property("box-sizing", value)
+}
+
+// Overflow declares how overflowing content is handled.
+func Overflow(values ...Value) Declaration {
+	return Property("overflow", values...)
+}
+
+// Visibility declares whether the element is visible.
+func Visibility(value Value) Declaration {
+	return Property("visibility", value)
+}
+
+// Opacity declares the opacity of the element.
+func Opacity(value Value) Declaration {
+	return Property("opacity", value)
+}
+
+// Color declares the text color of the element.
+func Color(value Value) Declaration {
+	return Property("color", value)
+}
+
+// Background declares the background of the element.
+func Background(values ...Value) Declaration {
+	return Property("background", values...)
+}
+
+// BackgroundColor declares the background color of the element.
+func BackgroundColor(value Value) Declaration {
+	return Property("background-color", value)
+}
+
+// BackgroundImage declares the background image of the element.
+func BackgroundImage(values ...Value) Declaration {
+	return Property("background-image", values...)
+}
+
+// FontFamily declares the font of the element.
+func FontFamily(values ...Value) Declaration {
+	return Property("font-family", values...)
+}
+
+// FontSize declares the font size of the element.
+func FontSize(value Value) Declaration {
+	return Property("font-size", value)
+}
+
+// FontWeight declares the font weight of the element.
+func FontWeight(value Value) Declaration {
+	return Property("font-weight", value)
+}
+
+// LineHeight declares the height of a line of text.
+func LineHeight(value Value) Declaration {
+	return Property("line-height", value)
+}
+
+// TextAlign declares the horizontal alignment of text.
+func TextAlign(value Value) Declaration {
+	return Property("text-align", value)
+}
+
+// TextDecoration declares the decoration of text.
+func TextDecoration(values ...Value) Declaration {
+	return Property("text-decoration", values...)
+}
+
+// WhiteSpace declares how white space is handled.
+func WhiteSpace(value Value) Declaration {
+	return Property("white-space", value)
+}
+
+// FlexDirection declares the direction of the items of a flex container.
+func FlexDirection(value Value) Declaration {
+	return Property("flex-direction", value)
+}
+
+// FlexWrap declares whether the items of a flex container wrap.
+func FlexWrap(value Value) Declaration {
+	return Property("flex-wrap", value)
+}
+
+// Flex_ declares how a flex item grows and shrinks.
+func Flex_(values ...Value) Declaration {
+	return Property("flex", values...)
+}
+
+// JustifyContent declares the alignment of the items along the main axis.
+func JustifyContent(value Value) Declaration {
+	return Property("justify-content", value)
+}
+
+// AlignItems declares the alignment of the items along the cross axis.
+func AlignItems(value Value) Declaration {
+	return Property("align-items", value)
+}
+
+// AlignSelf declares the alignment of the element along the cross axis of its container.
+func AlignSelf(value Value) Declaration {
+	return Property("align-self", value)
+}
+
+// Gap declares the gaps between rows and columns.
+func Gap(values ...Value) Declaration {
+	return Property("gap", values...)
+}
+
+// GridTemplateColumns declares the columns of a grid container.
+func GridTemplateColumns(values ...Value) Declaration {
+	return Property("grid-template-columns", values...)
+}
+
+// GridTemplateRows declares the rows of a grid container.
+func GridTemplateRows(values ...Value) Declaration {
+	return Property("grid-template-rows", values...)
+}
+
+// GridColumn declares the columns spanned by a grid item.
+func GridColumn(values ...Value) Declaration {
+	return Property("grid-column", values...)
+}
+
+// GridRow declares the rows spanned by a grid item.
+func GridRow(values ...Value) Declaration {
+	return Property("grid-row", values...)
+}
+
+// Cursor declares the mouse cursor over the element.
+func Cursor(value Value) Declaration {
+	return Property("cursor", value)
+}
+
+// Transform declares the transformations of the element.
+func Transform(values ...Value) Declaration {
+	return Property("transform", values...)
+}
+
+// Transition declares the transitions of the element.
+func Transition(values ...Value) Declaration {
+	return Property("transition", values...)
+}
+
+// Content declares the generated content of a pseudo element.
+func Content(values ...Value) Declaration {
+	return Property("content", values...)
+}
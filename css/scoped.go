@@ -0,0 +1,254 @@
+package css
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// Sheet holds the scoped rules of a component. The class names of the rules are generated from the name of the
+// component and the rules, so they do not collide with the classes of other components:
+//
+//	var styles = css.NewSheet("button")
+//	var primary = styles.Rule("primary", css.Color(css.Hex("fff")), css.BackgroundColor(css.Hex("06c"))).
+//		Pseudo(":hover", css.BackgroundColor(css.Hex("05a")))
+//
+//	Button(primary.Class())(Content("OK"))
+//	// <button class="button-primary-1a2b3c">OK</button>
+//
+// When the document is rendered with render.Document and Collect, only the rules used by the document are emitted
+// by the Styles placeholder.
+type Sheet struct {
+	component string
+
+	mu    sync.Mutex
+	rules []*Rule
+}
+
+// NewSheet returns a new Sheet for the component.
+func NewSheet(component string) *Sheet {
+	return &Sheet{component: component}
+}
+
+// Rule is a scoped rule, applied by the generated class name.
+type Rule struct {
+	// component and rule are the names the class name is generated from
+	component string
+	rule      string
+	seq       uint64
+	blocks    []block
+
+	once sync.Once
+	name string
+}
+
+// block is a block of declarations for the selector of the class followed by a suffix, like a pseudo class.
+type block struct {
+	suffix       string
+	declarations []Declaration
+}
+
+var (
+	// registry maps the generated class names to their rules
+	registry sync.Map
+	// sequence orders the rules by their creation, so the cascade of the emitted rules is stable
+	sequence   uint64
+	sequenceMu sync.Mutex
+)
+
+// Rule adds a rule with the declarations to the sheet. Its class name is generated from the name of the component,
+// the name of the rule and the hash of both and the declarations of all blocks of the rule, including the blocks
+// added by Pseudo.
+func (s *Sheet) Rule(name string, declarations ...Declaration) *Rule {
+	sequenceMu.Lock()
+	sequence++
+	seq := sequence
+	sequenceMu.Unlock()
+
+	r := &Rule{
+		component: s.component,
+		rule:      name,
+		seq:       seq,
+		blocks:    []block{{declarations: declarations}},
+	}
+
+	s.mu.Lock()
+	s.rules = append(s.rules, r)
+	s.mu.Unlock()
+	return r
+}
+
+// register generates the class name of the rule from all of its blocks, and registers the rule for Collect.
+// It panics if another rule with different blocks was registered with the same class name.
+func (r *Rule) register() {
+	hash := sha256.New()
+	hash.Write([]byte(r.component + "\x00" + r.rule))
+	for _, block := range r.blocks {
+		hash.Write([]byte("\x00" + block.suffix + "\x00" + Format(block.declarations)))
+	}
+	r.name = className(r.component + "-" + r.rule + "-" + hex.EncodeToString(hash.Sum(nil))[:6])
+
+	if existing, loaded := registry.LoadOrStore(r.name, r); loaded && existing.(*Rule).css() != r.css() {
+		panic(fmt.Sprintf("css: class name %q is already used by another rule", r.name))
+	}
+}
+
+// className returns s with all characters except ASCII letters, digits, '-' and '_' replaced by '-', so it can be
+// used as a class name in the class attribute and in selectors. A leading digit, also after a '-', is prefixed with '_',
+// as a class name must not start with a digit.
+func className(s string) string {
+	name := []byte(s)
+	for i, c := range name {
+		if !(c == '-' || c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			name[i] = '-'
+		}
+	}
+	digit := func(i int) bool { return i < len(name) && name[i] >= '0' && name[i] <= '9' }
+	if digit(0) || (len(name) > 0 && name[0] == '-' && digit(1)) {
+		return "_" + string(name)
+	}
+	return string(name)
+}
+
+// Pseudo adds declarations for a pseudo class or element of the rule, e.g. Pseudo(":hover", ...).
+// It must only be called while the rule is declared, as it is not safe for concurrent use with rendering.
+// It panics if the rule was already used, as its class name depends on all of its blocks.
+func (r *Rule) Pseudo(selector string, declarations ...Declaration) *Rule {
+	if r.name != "" {
+		panic(fmt.Sprintf("css: Pseudo called on rule %q after it was used", r.name))
+	}
+	r.blocks = append(r.blocks, block{suffix: selector, declarations: declarations})
+	return r
+}
+
+// Name returns the generated class name of the rule. It only contains ASCII letters, digits, '-' and '_'.
+func (r *Rule) Name() string {
+	r.once.Do(r.register)
+	return r.name
+}
+
+// Class returns the class attribute for the rule.
+//
+// This is a global types.Attribute.
+func (r *Rule) Class() types.Attribute {
+	return godom.Class(r.Name())
+}
+
+// String returns the rule in CSS syntax.
+func (r *Rule) String() string {
+	name := escapeIdent(r.Name())
+	var b strings.Builder
+	for _, block := range r.blocks {
+		b.WriteString("." + name + block.suffix + "{" + Format(block.declarations) + "}")
+	}
+	return b.String()
+}
+
+// css returns the blocks of the rule in CSS syntax, without the class name.
+func (r *Rule) css() string {
+	var b strings.Builder
+	for _, block := range r.blocks {
+		b.WriteString(block.suffix + "{" + Format(block.declarations) + "}")
+	}
+	return b.String()
+}
+
+// CSS returns all rules of the sheet, e.g. to serve them as an external stylesheet.
+func (s *Sheet) CSS() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var b strings.Builder
+	for _, r := range s.rules {
+		b.WriteString(r.String())
+	}
+	return b.String()
+}
+
+// collectorKey is the render.Local key of the used rules.
+type collectorKey struct{}
+
+// collector collects the rules used during a render.
+type collector struct {
+	mu   sync.Mutex
+	used map[*Rule]bool
+}
+
+func collectorFrom(w io.Writer) *collector {
+	c, _ := render.Local(w, collectorKey{}, func() any {
+		return &collector{used: make(map[*Rule]bool)}
+	}).(*collector)
+	return c
+}
+
+// hook records the rules of all class names of the element.
+func (c *collector) hook(_ string, attrs map[string]string, _ *[]string) error {
+	for _, class := range strings.Fields(attrs["class"]) {
+		if r, exists := registry.Load(class); exists {
+			c.mu.Lock()
+			c.used[r.(*Rule)] = true
+			c.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// Collect returns an element that records the scoped rules used by the element, so they are emitted by Styles.
+// Rules are only collected if the element is rendered by render.Document.
+func Collect(element types.Element) types.Element {
+	return &collectElement{element: element}
+}
+
+type collectElement struct {
+	element types.Element
+}
+
+func (c *collectElement) Render(writer io.Writer) error {
+	col := collectorFrom(writer)
+	if col == nil {
+		return c.element.Render(writer)
+	}
+	ctx := render.WithAttributeHook(render.Context(writer), col.hook)
+	return c.element.Render(render.WithContext(writer, ctx))
+}
+
+// Styles returns a placeholder element that emits a Style element with all scoped rules used by the document,
+// in the order they were declared. It emits nothing if no rules were used.
+func Styles(attrs ...types.Attribute) types.Element {
+	return render.Slot(func(w io.Writer) error {
+		col := collectorFrom(w)
+		if col == nil {
+			return nil
+		}
+
+		col.mu.Lock()
+		rules := make([]*Rule, 0, len(col.used))
+		for r := range col.used {
+			rules = append(rules, r)
+		}
+		col.mu.Unlock()
+		if len(rules) == 0 {
+			return nil
+		}
+		slices.SortFunc(rules, func(a, b *Rule) int {
+			return cmp.Compare(a.seq, b.seq)
+		})
+
+		var b strings.Builder
+		for _, r := range rules {
+			b.WriteString(r.String())
+		}
+		// the slot is filled with the context of its position, so the hooks of the render, like the nonce of the
+		// csp package, apply to the Style element
+		return godom.Style(attrs...)(helpers.NewStringElement(b.String())).Render(w)
+	})
+}
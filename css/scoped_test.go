@@ -0,0 +1,97 @@
+package css_test
+
+import (
+	"bytes"
+	"regexp"
+
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/csp"
+	"github.com/tbe/godom/css"
+	"github.com/tbe/godom/render"
+)
+
+var (
+	buttonStyles = css.NewSheet("button")
+	primary      = buttonStyles.Rule("primary", css.Color(css.Hex("fff"))).
+			Pseudo(":hover", css.Color(css.Hex("eee")))
+	secondary = buttonStyles.Rule("secondary", css.Color(css.Hex("000")))
+	unused    = buttonStyles.Rule("unused", css.Display(css.None))
+)
+
+func (suite *CSSTestSuite) TestRuleNames() {
+	suite.Regexp(regexp.MustCompile(`^button-primary-[0-9a-f]{6}$`), primary.Name())
+	suite.NotEqual(primary.Name(), css.NewSheet("link").Rule("primary", css.Color(css.Hex("fff"))).Name())
+	suite.Equal(
+		"."+primary.Name()+"{color:#fff}."+primary.Name()+":hover{color:#eee}",
+		primary.String(),
+	)
+	suite.Equal(primary.String()+secondary.String()+unused.String(), buttonStyles.CSS())
+}
+
+func (suite *CSSTestSuite) TestRuleNamesIncludePseudo() {
+	sheet := css.NewSheet("btn")
+	light := sheet.Rule("primary", css.Color(css.Hex("fff"))).Pseudo(":hover", css.Color(css.Hex("eee")))
+	dark := sheet.Rule("primary", css.Color(css.Hex("fff"))).Pseudo(":hover", css.Color(css.Hex("000")))
+	suite.NotEqual(light.Name(), dark.Name())
+
+	var buf bytes.Buffer
+	suite.NoError(render.Document(&buf, css.Collect(Div()(css.Styles(), Span(dark.Class())()))))
+	suite.Equal(`<div><style>`+dark.String()+`</style><span class="`+dark.Name()+`"></span></div>`, buf.String())
+
+	// the name depends on all blocks, so they can not be changed once the rule was used
+	suite.Panics(func() { light.Pseudo(":focus", css.Color(css.Hex("ddd"))) })
+}
+
+func (suite *CSSTestSuite) TestRuleNameCharacters() {
+	nested := css.NewSheet("forms/input.text").Rule("wide field", css.Width(css.Percent(100)))
+	suite.Regexp(regexp.MustCompile(`^forms-input-text-wide-field-[0-9a-f]{6}$`), nested.Name())
+	suite.Equal("."+nested.Name()+"{width:100%}", nested.String())
+
+	digit := css.NewSheet("2col").Rule("left", css.Width(css.Percent(50)))
+	suite.Regexp(regexp.MustCompile(`^_2col-left-[0-9a-f]{6}$`), digit.Name())
+	suite.Equal("."+digit.Name()+"{width:50%}", digit.String())
+
+	var buf bytes.Buffer
+	suite.NoError(Div(nested.Class(), digit.Class())().Render(&buf))
+	suite.Equal(`<div class="`+nested.Name()+` `+digit.Name()+`"></div>`, buf.String())
+}
+
+func (suite *CSSTestSuite) TestCollect() {
+	doc := css.Collect(HTML()(
+		Head()(css.Styles()),
+		Body()(
+			Button(secondary.Class())(Content("Cancel")),
+			Button(primary.Class(), Class("large"))(Content("OK")),
+			Button(primary.Class())(Content("Again")),
+		),
+	))
+
+	var buf bytes.Buffer
+	suite.NoError(render.Document(&buf, doc))
+	suite.Equal(
+		"<html><head><style>"+primary.String()+secondary.String()+"</style></head><body>"+
+			`<button class="`+secondary.Name()+`">Cancel</button>`+
+			`<button class="`+primary.Name()+` large">OK</button>`+
+			`<button class="`+primary.Name()+`">Again</button></body></html>`,
+		buf.String(),
+	)
+}
+
+func (suite *CSSTestSuite) TestStylesNonce() {
+	doc := csp.Nonce("abc", css.Collect(Div()(css.Styles(), Span(secondary.Class())())))
+
+	var buf bytes.Buffer
+	suite.NoError(render.Document(&buf, doc))
+	suite.Equal(`<div><style nonce="abc">`+secondary.String()+`</style><span class="`+secondary.Name()+`"></span></div>`, buf.String())
+}
+
+func (suite *CSSTestSuite) TestStylesWithoutRules() {
+	var buf bytes.Buffer
+	suite.NoError(render.Document(&buf, css.Collect(Div()(css.Styles()))))
+	suite.Equal(`<div></div>`, buf.String())
+
+	// without render.Document, nothing is collected
+	buf.Reset()
+	suite.NoError(css.Collect(Div(primary.Class())(css.Styles())).Render(&buf))
+	suite.Equal(`<div class="`+primary.Name()+`"></div>`, buf.String())
+}
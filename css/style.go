@@ -0,0 +1,84 @@
+/*
+Package css provides typed CSS declarations for inline styles:
+
+	Div(css.Style(
+		css.Display(css.Flex),
+		css.Margin(css.Px(4), css.Auto),
+		css.Custom("--gap", css.Rem(1)),
+		css.Gap(css.Var("--gap")),
+	))()
+
+The Style attribute can be used multiple times on the same element, e.g. by a component and its caller, and
+together with godom.StyleAttr. The declarations are merged, and if a property is declared more than once, the last
+declaration wins.
+
+Components can also declare scoped rules in a Sheet. The rules are applied by generated, collision-free class names,
+and only the rules used by a document are emitted by the Styles placeholder.
+*/
+package css
+
+import (
+	"strings"
+
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// Declaration is the declaration of a CSS property.
+type Declaration struct {
+	Property string
+	Value    Value
+}
+
+// String returns the declaration in CSS syntax.
+func (d Declaration) String() string {
+	return d.Property + ":" + string(d.Value)
+}
+
+// Property returns a declaration for an arbitrary property. Multiple values are separated by spaces.
+func Property(property string, values ...Value) Declaration {
+	return Declaration{Property: escapeIdent(property), Value: join(values, " ")}
+}
+
+// Custom returns a declaration of a custom property, e.g. Custom("--gap", Rem(1)).
+// The leading dashes are added if they are missing.
+func Custom(name string, values ...Value) Declaration {
+	if !strings.HasPrefix(name, "--") {
+		name = "--" + name
+	}
+	return Property(name, values...)
+}
+
+// Style returns the style attribute with the given declarations. If the element already has a style attribute,
+// e.g. set by godom.StyleAttr, the declarations are merged into it. Declarations of the same property replace the
+// earlier ones.
+//
+// This is a global types.Attribute.
+func Style(declarations ...Declaration) types.Attribute {
+	converted := make([]helpers.StyleDeclaration, len(declarations))
+	for i, d := range declarations {
+		converted[i] = helpers.StyleDeclaration{Property: d.Property, Value: string(d.Value)}
+	}
+	return func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+		attrs["style"] = helpers.MergeStyle(attrs["style"], converted...)
+	}
+}
+
+// Format returns the declarations in the syntax of the style attribute.
+func Format(declarations []Declaration) string {
+	parts := make([]string, len(declarations))
+	for i, d := range declarations {
+		parts[i] = d.String()
+	}
+	return strings.Join(parts, ";")
+}
+
+// Parse splits the value of a style attribute into its declarations. Semicolons inside of strings
+// and parentheses do not end a declaration. Invalid declarations are dropped.
+func Parse(style string) []Declaration {
+	var declarations []Declaration
+	for _, d := range helpers.ParseStyle(style) {
+		declarations = append(declarations, Declaration{Property: d.Property, Value: Value(d.Value)})
+	}
+	return declarations
+}
@@ -0,0 +1,204 @@
+package css
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Value is a CSS value. Values created by the functions of this package are escaped, converting a string to Value
+// embeds it as is.
+type Value string
+
+// Common keyword values.
+const (
+	Auto         Value = "auto"
+	None         Value = "none"
+	Inherit      Value = "inherit"
+	Initial      Value = "initial"
+	Unset        Value = "unset"
+	Block        Value = "block"
+	Inline       Value = "inline"
+	InlineBlock  Value = "inline-block"
+	Flex         Value = "flex"
+	InlineFlex   Value = "inline-flex"
+	Grid         Value = "grid"
+	Contents     Value = "contents"
+	Relative     Value = "relative"
+	Absolute     Value = "absolute"
+	Fixed        Value = "fixed"
+	Sticky       Value = "sticky"
+	Static       Value = "static"
+	Row          Value = "row"
+	Column       Value = "column"
+	Center       Value = "center"
+	Start        Value = "start"
+	End          Value = "end"
+	FlexStart    Value = "flex-start"
+	FlexEnd      Value = "flex-end"
+	Stretch      Value = "stretch"
+	SpaceAround  Value = "space-around"
+	SpaceEvenly  Value = "space-evenly"
+	SpaceBetween Value = "space-between"
+	Hidden       Value = "hidden"
+	Visible      Value = "visible"
+	Scroll       Value = "scroll"
+	Bold         Value = "bold"
+	Normal       Value = "normal"
+	Pointer      Value = "pointer"
+	Transparent  Value = "transparent"
+	CurrentColor Value = "currentcolor"
+)
+
+// Number is a numeric type usable for CSS dimensions.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+func format[T Number](n T) string {
+	return strconv.FormatFloat(float64(n), 'f', -1, 64)
+}
+
+// Num returns a number without unit.
+func Num[T Number](n T) Value {
+	return Value(format(n))
+}
+
+// Px returns a length in pixels.
+func Px[T Number](n T) Value {
+	return Value(format(n) + "px")
+}
+
+// Em returns a length relative to the font size of the element.
+func Em[T Number](n T) Value {
+	return Value(format(n) + "em")
+}
+
+// Rem returns a length relative to the font size of the root element.
+func Rem[T Number](n T) Value {
+	return Value(format(n) + "rem")
+}
+
+// Percent returns a percentage.
+func Percent[T Number](n T) Value {
+	return Value(format(n) + "%")
+}
+
+// Vw returns a length relative to the width of the viewport.
+func Vw[T Number](n T) Value {
+	return Value(format(n) + "vw")
+}
+
+// Vh returns a length relative to the height of the viewport.
+func Vh[T Number](n T) Value {
+	return Value(format(n) + "vh")
+}
+
+// Fr returns a fraction of the free space in a grid container.
+func Fr[T Number](n T) Value {
+	return Value(format(n) + "fr")
+}
+
+// Ms returns a duration in milliseconds.
+func Ms[T Number](n T) Value {
+	return Value(format(n) + "ms")
+}
+
+// RGB returns a color from its red, green and blue components.
+func RGB(r, g, b uint8) Value {
+	return Value("rgb(" + format(r) + " " + format(g) + " " + format(b) + ")")
+}
+
+// RGBA returns a color from its red, green and blue components and its opacity between 0 and 1.
+func RGBA(r, g, b uint8, alpha float64) Value {
+	return Value("rgb(" + format(r) + " " + format(g) + " " + format(b) + " / " + format(alpha) + ")")
+}
+
+// Hex returns a color in hexadecimal notation, e.g. Hex("ff0000"). Invalid characters are removed.
+func Hex(color string) Value {
+	color = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F') {
+			return r
+		}
+		return -1
+	}, color)
+	return Value("#" + color)
+}
+
+// Var returns a reference to the custom property, e.g. Var("--gap") for `var(--gap)`.
+// Fallback values are used if the custom property is not set.
+func Var(name string, fallback ...Value) Value {
+	ref := "var(" + escapeIdent(name)
+	if len(fallback) > 0 {
+		ref += ", " + string(join(fallback, " "))
+	}
+	return Value(ref + ")")
+}
+
+// String returns a quoted string, e.g. for the content property.
+func String(s string) Value {
+	return Value(escapeString(s))
+}
+
+// URL returns a URL value.
+func URL(url string) Value {
+	return Value("url(" + escapeString(url) + ")")
+}
+
+// Keyword returns an identifier value, e.g. a keyword that is not defined by this package or the name of an animation.
+func Keyword(ident string) Value {
+	return Value(escapeIdent(ident))
+}
+
+// Calc returns a calculation, e.g. Calc(Percent(100), "-", Px(20)).
+func Calc(values ...Value) Value {
+	return Value("calc(" + string(join(values, " ")) + ")")
+}
+
+// join joins the values with the separator.
+func join(values []Value, sep string) Value {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = string(v)
+	}
+	return Value(strings.Join(parts, sep))
+}
+
+// escapeString quotes s as CSS string. Quotes, backslashes, control characters and `<` are escaped, so the string
+// can neither end the value or the declaration, nor a Style element.
+func escapeString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f || r == '<':
+			b.WriteString("\\" + strconv.FormatInt(int64(r), 16) + " ")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// escapeIdent escapes all characters of s that are not allowed in a CSS identifier.
+func escapeIdent(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '-' || r == '_' || r >= 0x80 ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			b.WriteRune(r)
+		case r >= '0' && r <= '9' && i > 0:
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f || (r >= '0' && r <= '9'):
+			b.WriteString("\\" + strconv.FormatInt(int64(r), 16) + " ")
+		default:
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
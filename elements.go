@@ -2,7 +2,6 @@ package godom
 
 import (
 	"html"
-	"io"
 
 	"github.com/tbe/godom/helpers"
 	"github.com/tbe/godom/types"
@@ -11,7 +10,7 @@ import (
 // Group is a wrapper that can hold 0...N children. This allows to hold a full document
 // or can be used in places where multiple elements are required, but only a single types.Element is allowed.
 func Group(children ...types.Element) types.Element {
-	return &container{children: children}
+	return helpers.NewContainer(children...)
 }
 
 // The Doctype provides the DOCTYPE declaration
@@ -585,16 +584,3 @@ func WBr(attrs ...types.Attribute) types.Element {
 func Content(content string) types.Element {
 	return helpers.NewStringElement(html.EscapeString(content))
 }
-
-type container struct {
-	children []types.Element
-}
-
-func (c *container) Render(writer io.Writer) error {
-	for _, child := range c.children {
-		if err := child.Render(writer); err != nil {
-			return err
-		}
-	}
-	return nil
-}
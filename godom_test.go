@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	. "github.com/tbe/godom"
+	"github.com/tbe/godom/helpers"
 	"github.com/tbe/godom/types"
 	"github.com/tbe/godom/util"
 	"github.com/tdewolff/minify/v2"
@@ -122,8 +123,74 @@ func TestDocRender(t *testing.T) {
 	assert.Equal(t, minifedExpected, minifiedRendered)
 }
 
+func TestAttributeValueEscaping(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Div(TitleAttr("say \"hi\"\n<now> & 'later'"), Data_("path", `C:\temp`))().Render(&buf))
+	assert.Equal(t, `<div data-path="C:\temp" title="say &#34;hi&#34;`+"\n"+`&lt;now&gt; &amp; &#39;later&#39;"></div>`, buf.String())
+}
+
 func TestDuplicateAttribute(t *testing.T) {
 	attrs := make(map[string]string)
 	attrs["href"] = "somelink"
 	assert.Panics(t, func() { HRef("abcd")(attrs, nil, nil) })
 }
+
+// blogPost returns the blog post of the README.
+func blogPost(isFeatured, showAuthorBio bool) types.Element {
+	return Group(
+		Doctype(),
+		HTML()(
+			Header()(
+				Meta(Charset("utf-8")),
+				Title()(Content("Blog Post Title")),
+			),
+			Body()(
+				Div(Class("blog-post"), util.IfAttr(isFeatured, Class("featured")),
+					util.DelayedAttribute(func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+						if isFeatured {
+							attrs["data-highlight"] = "true"
+						}
+					}),
+				)(
+					H1()(Content("Blog Post Title")),
+					P()(Content("This is the introduction of the blog post.")),
+					util.DelayedElement(func() types.Element {
+						if isFeatured {
+							return P(Class("featured-note"))(Content("This is a featured article!"))
+						}
+						return Group()
+					}),
+					util.IfElem(showAuthorBio, Div(Class("author-bio"))(
+						P()(Content("This is the author's bio. It provides information about the author.")),
+					)),
+				),
+			),
+		),
+	)
+}
+
+func TestCompileBlogPost(t *testing.T) {
+	var expected, compiled bytes.Buffer
+	assert.NoError(t, blogPost(true, true).Render(&expected))
+	assert.NoError(t, helpers.Compile(blogPost(true, true)).Render(&compiled))
+	assert.Equal(t, expected.String(), compiled.String())
+}
+
+func benchmarkBlogPost(b *testing.B, element types.Element) {
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := element.Render(&buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	benchmarkBlogPost(b, blogPost(true, true))
+}
+
+func BenchmarkRenderCompiled(b *testing.B) {
+	benchmarkBlogPost(b, helpers.Compile(blogPost(true, true)))
+}
@@ -0,0 +1,48 @@
+package head
+
+import (
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// Assets returns a placeholder element that emits all assets required during the render, like the stylesheets
+// and scripts of components. It is usually placed in the Head of the document:
+//
+//	head.Head()(
+//		head.Title("My Site"),
+//		head.Assets(),
+//	)
+//
+// Assets are entries that are emitted by Assets instead of Head. They are deduplicated like all entries,
+// so every component can require its assets, no matter how often it is part of the document.
+func Assets() types.Element {
+	return emit(assetsKey{})
+}
+
+// Asset returns an asset for an arbitrary element. Assets with the same key are deduplicated.
+func Asset(key string, element types.Element) Entry {
+	return Entry{target: assetsKey{}, key: key, element: element}
+}
+
+// Stylesheet returns the asset for the stylesheet at href, deduplicated by its URL.
+func Stylesheet(href string, attrs ...types.Attribute) Entry {
+	attrs = append([]types.Attribute{godom.Rel("stylesheet"), godom.HRef(href)}, attrs...)
+	return Asset("stylesheet:"+href, godom.Link(attrs...))
+}
+
+// Script returns the asset for the script at src, deduplicated by its URL.
+func Script(src string, attrs ...types.Attribute) Entry {
+	attrs = append([]types.Attribute{godom.Src(src)}, attrs...)
+	return Asset("script:"+src, godom.Script(attrs...)())
+}
+
+// InlineStyle returns the asset for an inline stylesheet, deduplicated by the key. The css is not escaped.
+func InlineStyle(key, css string, attrs ...types.Attribute) Entry {
+	return Asset("style:"+key, godom.Style(attrs...)(helpers.NewStringElement(css)))
+}
+
+// InlineScript returns the asset for an inline script, deduplicated by the key. The script is not escaped.
+func InlineScript(key, script string, attrs ...types.Attribute) Entry {
+	return Asset("inline-script:"+key, godom.Script(attrs...)(helpers.NewStringElement(script)))
+}
@@ -0,0 +1,46 @@
+package head_test
+
+import (
+	"bytes"
+
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/head"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+func widget(label string) types.Element {
+	return helpers.NewElement("x-widget")(
+		head.Stylesheet("/widget.css"),
+		head.Script("/widget.js", Defer()),
+		head.InlineStyle("widget", "x-widget { display: block; }"),
+		Content(label),
+	)
+}
+
+func (suite *HeadTestSuite) TestAssets() {
+	doc := HTML()(
+		head.Head()(
+			head.Title("Title"),
+			head.Assets(),
+		),
+		Body()(
+			widget("first"),
+			widget("second"),
+			head.InlineScript("init", "init();"),
+		),
+	)
+
+	suite.Equal(
+		`<html><head><title>Title</title><link href="/widget.css" rel="stylesheet"/><script defer src="/widget.js"></script>`+
+			`<style>x-widget { display: block; }</style><script>init();</script></head>`+
+			`<body><x-widget>first</x-widget><x-widget>second</x-widget></body></html>`,
+		suite.render(doc),
+	)
+}
+
+func (suite *HeadTestSuite) TestAssetsWithoutDocument() {
+	var buf bytes.Buffer
+	suite.NoError(Body()(head.Assets(), head.Stylesheet("/main.css")).Render(&buf))
+	suite.Equal(`<body><link href="/main.css" rel="stylesheet"/></body>`, buf.String())
+}
@@ -0,0 +1,218 @@
+/*
+Package head manages the metadata in the head of a document.
+
+Every entry, like a title, a description or an OpenGraph property, is an element that can be placed anywhere in the
+element tree. When the document is rendered with render.Document, all entries are collected and emitted by the
+Head element of the document, so nested components can contribute to the head:
+
+	page := HTML()(
+		head.Head()(
+			head.Charset("utf-8"),
+			head.Title("My Site"),
+			head.Icon("/favicon.ico"),
+		),
+		Body()(
+			article(post),
+		),
+	)
+
+	func article(post Post) types.Element {
+		return Article()(
+			head.Title(post.Title),
+			head.Description(post.Summary),
+			head.OpenGraph("title", post.Title),
+			H1()(Content(post.Title)),
+		)
+	}
+
+Entries are deduplicated: for every title, name, property or URL only one entry is emitted. It is emitted at the
+position the entry was first contributed at, with the value of the entry contributed last. In the example above,
+the title of the article replaces the title of the site.
+
+Components can require their stylesheets and scripts the same way, using assets like Stylesheet or Script.
+Assets are emitted by the Assets placeholder, which is usually placed in the Head.
+
+Outside of render.Document, entries are rendered in place. Streaming renders, like stream.Render, write the
+head before the body is rendered, so entries can not be collected: rendering an entry fails with ErrStreaming.
+Likewise, cached subtrees of the cache package are rendered detached from the document, and rendering an entry
+in them fails with ErrDetached.
+*/
+package head
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// ErrStreaming is returned when an Entry is rendered by a streaming render (see render.Streaming).
+var ErrStreaming = errors.New("head: entries can not be collected by a streaming render")
+
+// ErrDetached is returned when an Entry is rendered detached from render.Document (see render.Detached), e.g. in a
+// cached subtree.
+var ErrDetached = errors.New("head: entries can not be collected by a detached render")
+
+// Entry is an entry in the head of a document. All entries with the same key are deduplicated.
+type Entry struct {
+	target  any
+	key     string
+	element types.Element
+}
+
+// Custom returns an Entry for an arbitrary element. Entries with the same key are deduplicated.
+func Custom(key string, element types.Element) Entry {
+	return Entry{target: entriesKey{}, key: key, element: element}
+}
+
+// Key returns the deduplication key of the entry.
+func (e Entry) Key() string {
+	return e.key
+}
+
+// Render contributes the entry to the Head of the document, or to Assets if it is an asset.
+// Outside of render.Document, the entry is rendered in place. In streaming renders, ErrStreaming is returned, and
+// in detached renders, like cached subtrees, ErrDetached.
+func (e Entry) Render(writer io.Writer) error {
+	c := collectorFrom(writer, e.target)
+	if c == nil {
+		if render.Streaming(writer) {
+			return ErrStreaming
+		}
+		if render.Detached(writer) {
+			return ErrDetached
+		}
+		return e.element.Render(writer)
+	}
+	c.add(e)
+	return nil
+}
+
+// The render.Local keys of the collectors for the entries of Head and Assets.
+type (
+	entriesKey struct{}
+	assetsKey  struct{}
+)
+
+// collector collects the entries contributed during a render.
+type collector struct {
+	mu      sync.Mutex
+	keys    []string
+	entries map[string]types.Element
+}
+
+func collectorFrom(w io.Writer, key any) *collector {
+	c, _ := render.Local(w, key, func() any {
+		return &collector{entries: make(map[string]types.Element)}
+	}).(*collector)
+	return c
+}
+
+func (c *collector) add(e Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[e.key]; !exists {
+		c.keys = append(c.keys, e.key)
+	}
+	c.entries[e.key] = e.element
+}
+
+func (c *collector) render(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range c.keys {
+		if err := c.entries[key].Render(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Head returns the Head element of the document. It emits all entries contributed during the render, followed by
+// its other children.
+func Head(attrs ...types.Attribute) types.ElementFactory {
+	factory := godom.Head(attrs...)
+	return func(children ...types.Element) types.Element {
+		return factory(append([]types.Element{emit(entriesKey{})}, children...)...)
+	}
+}
+
+// emit returns the slot that renders the entries of the collector.
+func emit(key any) types.Element {
+	return render.Slot(func(w io.Writer) error {
+		if c := collectorFrom(w, key); c != nil {
+			return c.render(w)
+		}
+		return nil
+	})
+}
+
+// Charset returns the Entry declaring the character encoding of the document.
+func Charset(charset string) Entry {
+	return Custom("charset", godom.Meta(godom.Charset(charset)))
+}
+
+// Title returns the Entry for the title of the document.
+func Title(title string) Entry {
+	return Custom("title", godom.Title()(godom.Content(title)))
+}
+
+// Meta returns the Entry for the named metadata, e.g. Meta("viewport", "width=device-width").
+func Meta(name, content string) Entry {
+	return Custom("name:"+name, godom.Meta(godom.Name(name), godom.ContentAttr(content)))
+}
+
+// Description returns the Entry for the description of the document.
+func Description(description string) Entry {
+	return Meta("description", description)
+}
+
+// Canonical returns the Entry for the canonical URL of the document.
+func Canonical(url string) Entry {
+	return Custom("canonical", godom.Link(godom.Rel("canonical"), godom.HRef(url)))
+}
+
+// Property returns the Entry for the metadata property, as used by OpenGraph.
+func Property(property, content string) Entry {
+	return Custom("property:"+property, godom.Meta(helpers.SingleAttribute("property", property), godom.ContentAttr(content)))
+}
+
+// OpenGraph returns the Entry for the OpenGraph property, e.g. OpenGraph("title", "My Site") for `og:title`.
+func OpenGraph(property, content string) Entry {
+	return Property("og:"+property, content)
+}
+
+// Twitter returns the Entry for the Twitter card property, e.g. Twitter("card", "summary") for `twitter:card`.
+func Twitter(name, content string) Entry {
+	return Meta("twitter:"+name, content)
+}
+
+// Icon returns the Entry for an icon of the document. Additional attributes, like Type or Sizes, can be given.
+func Icon(href string, attrs ...types.Attribute) Entry {
+	return link("icon", href, attrs)
+}
+
+// AppleTouchIcon returns the Entry for an icon used by iOS devices.
+func AppleTouchIcon(href string, attrs ...types.Attribute) Entry {
+	return link("apple-touch-icon", href, attrs)
+}
+
+// Preload returns the Entry to preload a resource of the given type, e.g. Preload("/font.woff2", "font").
+func Preload(href, as string, attrs ...types.Attribute) Entry {
+	return link("preload", href, append([]types.Attribute{helpers.SingleAttribute("as", as)}, attrs...))
+}
+
+// Preconnect returns the Entry to connect to an origin in advance.
+func Preconnect(href string, attrs ...types.Attribute) Entry {
+	return link("preconnect", href, attrs)
+}
+
+// link returns the Entry for a Link element, deduplicated by relationship and URL.
+func link(rel, href string, attrs []types.Attribute) Entry {
+	attrs = append([]types.Attribute{godom.Rel(rel), godom.HRef(href)}, attrs...)
+	return Custom(rel+":"+href, godom.Link(attrs...))
+}
@@ -0,0 +1,108 @@
+package head_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/head"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+func TestHead(t *testing.T) {
+	suite.Run(t, new(HeadTestSuite))
+}
+
+type HeadTestSuite struct {
+	suite.Suite
+}
+
+func (suite *HeadTestSuite) render(element types.Element) string {
+	var buf bytes.Buffer
+	suite.NoError(render.Document(&buf, element))
+	return buf.String()
+}
+
+func (suite *HeadTestSuite) TestEntries() {
+	doc := head.Head()(
+		head.Charset("utf-8"),
+		head.Title("Title"),
+		head.Description("Description"),
+		head.Canonical("https://example.com/"),
+		head.OpenGraph("title", "OG Title"),
+		head.Twitter("card", "summary"),
+		head.Icon("/favicon.svg", Type("image/svg+xml")),
+		head.AppleTouchIcon("/apple-touch-icon.png"),
+		head.Preload("/font.woff2", "font", CrossOrigin("anonymous")),
+		head.Preconnect("https://cdn.example.com"),
+	)
+
+	suite.Equal(
+		`<head><meta charset="utf-8"/><title>Title</title><meta content="Description" name="description"/>`+
+			`<link href="https://example.com/" rel="canonical"/><meta content="OG Title" property="og:title"/>`+
+			`<meta content="summary" name="twitter:card"/><link href="/favicon.svg" rel="icon" type="image/svg+xml"/>`+
+			`<link href="/apple-touch-icon.png" rel="apple-touch-icon"/>`+
+			`<link as="font" crossorigin="anonymous" href="/font.woff2" rel="preload"/>`+
+			`<link href="https://cdn.example.com" rel="preconnect"/></head>`,
+		suite.render(doc),
+	)
+}
+
+func (suite *HeadTestSuite) TestNestedContributions() {
+	article := func(title string) types.Element {
+		return Article()(
+			head.Title(title),
+			head.OpenGraph("title", title),
+			head.Preconnect("https://cdn.example.com"),
+			H1()(Content(title)),
+		)
+	}
+
+	doc := HTML()(
+		head.Head()(
+			head.Charset("utf-8"),
+			head.Title("Site"),
+			head.Preconnect("https://cdn.example.com"),
+			Script(Src("/app.js"))(),
+		),
+		Body()(article("Article")),
+	)
+
+	suite.Equal(
+		`<html><head><meta charset="utf-8"/><title>Article</title><link href="https://cdn.example.com" rel="preconnect"/>`+
+			`<meta content="Article" property="og:title"/><script src="/app.js"></script></head>`+
+			`<body><article><h1>Article</h1></article></body></html>`,
+		suite.render(doc),
+	)
+}
+
+func (suite *HeadTestSuite) TestWithoutDocument() {
+	doc := head.Head()(head.Title("Title"))
+
+	var buf bytes.Buffer
+	suite.NoError(doc.Render(&buf))
+	suite.Equal(`<head><title>Title</title></head>`, buf.String())
+}
+
+func (suite *HeadTestSuite) TestCustom() {
+	entry := head.Custom("manifest", Link(Rel("manifest"), HRef("/manifest.json")))
+	suite.Equal("manifest", entry.Key())
+	suite.Equal(
+		`<head><link href="/manifest.json" rel="manifest"/></head>`,
+		suite.render(head.Head()(entry, head.Custom("manifest", Link(Rel("manifest"), HRef("/manifest.json"))))),
+	)
+}
+
+func (suite *HeadTestSuite) TestStreaming() {
+	var buf bytes.Buffer
+	ctx := render.WithStreaming(context.Background())
+	suite.ErrorIs(head.Title("Title").Render(render.WithContext(&buf, ctx)), head.ErrStreaming)
+
+	// a document rendered in a streaming render still collects its entries
+	buf.Reset()
+	suite.NoError(render.Document(render.WithContext(&buf, ctx), head.Head()(head.Title("Title"))))
+	suite.Equal(`<head><title>Title</title></head>`, buf.String())
+}
@@ -0,0 +1,155 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// compiledElement is an element tree with all static parts rendered in advance.
+type compiledElement struct {
+	original types.Element
+	mode     AttributeOrder
+	parts    []compiledPart
+	// dynamic holds the tags of elements whose start tag is rendered dynamically
+	dynamic []string
+	// static holds the tags of elements whose start tag is part of a static chunk
+	static map[string]bool
+}
+
+// compiledPart is a static chunk, followed by an optional dynamic hole.
+type compiledPart struct {
+	static []byte
+	// element is a dynamic element that is rendered as is
+	element types.Element
+	// open is an element with delayed attributes or a dynamic tag, of which only the start tag up to the attributes
+	// is rendered
+	open *childlessElement
+}
+
+// Compile pre-renders all static parts of the element tree, so rendering the returned element only writes
+// precomputed chunks and renders the dynamic parts. Elements created by this package without delayed attributes,
+// string elements and containers are static. Elements with delayed attributes only render their start tag
+// dynamically, and all other elements, like delayed elements, are rendered as is.
+//
+// The start tags of elements with one of the dynamicTags are rendered dynamically as well, so attribute hooks for
+// these tags (see render.WithAttributeHook) are applied to them. For example, compile a page with
+// Compile(page, csp.Tags...) to render it with the nonces of the csp package.
+//
+// The tree must not be changed after it was compiled. The static parts are rendered with the AttributeOrder
+// set by SetAttributeOrder. If a render uses a different AttributeOrder, attribute hooks for all tags, or
+// attribute hooks for a tag of a static start tag, the original element is rendered instead, so the output is
+// always the same as the output of the original element.
+func Compile(element types.Element, dynamicTags ...string) types.Element {
+	c := &compiledElement{
+		original: element,
+		mode:     AttributeOrder(defaultOrder.Load()),
+		dynamic:  dynamicTags,
+		static:   make(map[string]bool),
+	}
+	var static bytes.Buffer
+	c.compile(element, &static)
+	if static.Len() > 0 {
+		c.parts = append(c.parts, compiledPart{static: static.Bytes()})
+	}
+	return c
+}
+
+// compile appends the element to the static chunk, or adds a part with the chunk and the dynamic element.
+func (c *compiledElement) compile(el types.Element, static *bytes.Buffer) {
+	writer := render.WithContext(static, WithAttributeOrder(context.Background(), c.mode))
+
+	switch e := el.(type) {
+	case *stringElement:
+		static.Write(e.data)
+	case *container:
+		for _, child := range e.children {
+			c.compile(child, static)
+		}
+	case *childlessElement:
+		if c.isDynamic(e) {
+			c.hole(compiledPart{open: e}, static)
+		} else {
+			c.static[e.tag] = true
+			static.WriteString("<" + e.tag)
+			// without hooks, rendering the attributes can not fail
+			_ = e.renderAttributes(writer)
+		}
+		static.WriteString("/>")
+	case *element:
+		if c.isDynamic(&e.childlessElement) {
+			c.hole(compiledPart{open: &e.childlessElement}, static)
+		} else {
+			c.static[e.tag] = true
+			static.WriteString("<" + e.tag)
+			_ = e.renderAttributes(writer)
+		}
+		static.WriteString(">")
+		for _, child := range e.children {
+			c.compile(child, static)
+		}
+		static.WriteString("</" + e.tag + ">")
+	default:
+		c.hole(compiledPart{element: el}, static)
+	}
+}
+
+// isDynamic reports whether the start tag of the element must be rendered dynamically.
+func (c *compiledElement) isDynamic(e *childlessElement) bool {
+	return len(e.delayedAttributes) > 0 || slices.Contains(c.dynamic, e.tag)
+}
+
+// hole adds the part with the current static chunk, and starts a new chunk.
+func (c *compiledElement) hole(part compiledPart, static *bytes.Buffer) {
+	part.static = bytes.Clone(static.Bytes())
+	c.parts = append(c.parts, part)
+	static.Reset()
+}
+
+// covers reports whether the static chunks are the same as the output of the original element for the writer.
+func (c *compiledElement) covers(writer io.Writer) bool {
+	if attributeOrder(writer) != c.mode {
+		return false
+	}
+	tags, all := render.AttributeHookTags(writer)
+	if all {
+		return false
+	}
+	for _, tag := range tags {
+		if c.static[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// Render writes the static chunks and renders the dynamic parts of the element.
+func (c *compiledElement) Render(writer io.Writer) error {
+	if !c.covers(writer) {
+		return c.original.Render(writer)
+	}
+
+	for _, part := range c.parts {
+		if _, err := writer.Write(part.static); err != nil {
+			return err
+		}
+		switch {
+		case part.element != nil:
+			if err := part.element.Render(writer); err != nil {
+				return err
+			}
+		case part.open != nil:
+			if err := openTag(writer, part.open.tag); err != nil {
+				return err
+			}
+			if err := part.open.renderAttributes(writer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
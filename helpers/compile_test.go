@@ -0,0 +1,98 @@
+package helpers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// dynamicText renders the current value of text.
+type dynamicText struct {
+	text *string
+}
+
+func (d dynamicText) Render(w io.Writer) error {
+	_, err := io.WriteString(w, *d.text)
+	return err
+}
+
+func (s *HelpersTestSuite) TestCompile() {
+	text, title := "first", "a"
+	delayed := func(_ map[string]string, _ *[]string, delayed *[]types.Attribute) {
+		*delayed = append(*delayed, func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+			attrs["title"] = title
+		})
+	}
+	element := helpers.NewContainer(
+		helpers.NewStringElement("<!DOCTYPE html>"),
+		helpers.NewElement("div", helpers.MultiValueAttribute("class", "b a"), helpers.SingleAttribute("id", "x"))(
+			helpers.NewChildlessElement("br", helpers.FlagAttribute("hidden")),
+			helpers.NewElement("p", delayed)(helpers.NewStringElement("static"), dynamicText{&text}),
+			helpers.NewChildlessElement("input", delayed),
+		),
+	)
+	compiled := helpers.Compile(element)
+
+	var buf bytes.Buffer
+	assert.NoError(s.T(), compiled.Render(&buf))
+	assert.Equal(s.T(),
+		`<!DOCTYPE html><div class="b a" id="x"><br hidden/><p title="a">staticfirst</p><input title="a"/></div>`,
+		buf.String())
+
+	text, title = "second", "b"
+	buf.Reset()
+	assert.NoError(s.T(), compiled.Render(&buf))
+	assert.Equal(s.T(),
+		`<!DOCTYPE html><div class="b a" id="x"><br hidden/><p title="b">staticsecond</p><input title="b"/></div>`,
+		buf.String())
+}
+
+func (s *HelpersTestSuite) TestCompileFallback() {
+	element := helpers.NewElement("div", helpers.SingleAttribute("title", "x"), helpers.SingleAttribute("id", "y"))()
+	compiled := helpers.Compile(element)
+
+	// a different attribute order renders the original element
+	assert.Equal(s.T(), `<div title="x" id="y"></div>`, s.renderOrdered(helpers.InsertionOrder, compiled))
+
+	// as do attribute hooks
+	ctx := render.WithAttributeHook(context.Background(), func(_ string, attrs map[string]string, _ *[]string) error {
+		attrs["nonce"] = "abc"
+		return nil
+	})
+	var buf bytes.Buffer
+	assert.NoError(s.T(), compiled.Render(render.WithContext(&buf, ctx)))
+	assert.Equal(s.T(), `<div id="y" nonce="abc" title="x"></div>`, buf.String())
+}
+
+func (s *HelpersTestSuite) TestCompileDynamicTags() {
+	element := helpers.NewElement("div", helpers.SingleAttribute("id", "x"))(
+		helpers.NewElement("script", helpers.SingleAttribute("src", "app.js"))(),
+	)
+	nonce := func(_ string, attrs map[string]string, _ *[]string) error {
+		attrs["nonce"] = "abc"
+		return nil
+	}
+
+	// hooks for dynamic tags are applied to the compiled element
+	var buf bytes.Buffer
+	ctx := render.WithAttributeHook(context.Background(), nonce, "script")
+	assert.NoError(s.T(), helpers.Compile(element, "script").Render(render.WithContext(&buf, ctx)))
+	assert.Equal(s.T(), `<div id="x"><script nonce="abc" src="app.js"></script></div>`, buf.String())
+
+	// hooks for tags that are not in the tree do not affect the compiled element
+	buf.Reset()
+	ctx = render.WithAttributeHook(context.Background(), nonce, "style")
+	assert.NoError(s.T(), helpers.Compile(element).Render(render.WithContext(&buf, ctx)))
+	assert.Equal(s.T(), `<div id="x"><script src="app.js"></script></div>`, buf.String())
+
+	// hooks for static tags render the original element
+	buf.Reset()
+	ctx = render.WithAttributeHook(context.Background(), nonce, "div")
+	assert.NoError(s.T(), helpers.Compile(element, "script").Render(render.WithContext(&buf, ctx)))
+	assert.Equal(s.T(), `<div id="x" nonce="abc"><script src="app.js"></script></div>`, buf.String())
+}
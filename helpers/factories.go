@@ -4,10 +4,15 @@ package helpers
 
 import (
 	"fmt"
+	"html"
 	"io"
 	"slices"
 	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/tbe/godom/render"
 	"github.com/tbe/godom/types"
 	"golang.org/x/exp/maps"
 )
@@ -19,6 +24,10 @@ type childlessElement struct {
 	attributes        map[string]string
 	flags             []string
 	delayedAttributes []types.Attribute
+	// order holds the names of the attributes and flags in the order they were added
+	order []string
+	// formatted caches the formatted attributes per AttributeOrder, if there are no delayed attributes
+	formatted [CanonicalOrder + 1]atomic.Pointer[string]
 }
 
 // NewChildlessElement creates a new types.Element that cannot have child elements.
@@ -29,65 +38,107 @@ func NewChildlessElement(tag string, attrs ...types.Attribute) types.Element {
 		tag:        tag,
 		attributes: make(map[string]string),
 	}
-	for _, attr := range attrs {
-		attr(e.attributes, &e.flags, &e.delayedAttributes)
-	}
+	e.apply(attrs)
 
 	return e
 }
 
+// apply applies the attributes to the element, recording the order of the added attributes and flags.
+func (ce *childlessElement) apply(attrs []types.Attribute) {
+	for _, attr := range attrs {
+		ce.order = track(ce.attributes, &ce.flags, ce.order, func() {
+			attr(ce.attributes, &ce.flags, &ce.delayedAttributes)
+		})
+	}
+}
+
 // renderAttributes writes the attributes of the element to the provided writer.
-// It ensures that attributes are ordered consistently for predictable output.
+// It applies the delayed attributes and the render.AttributeHooks of the writer for the tag of the element, and
+// orders the attributes by the AttributeOrder of the render for predictable output.
 func (ce *childlessElement) renderAttributes(writer io.Writer) error {
-	var attrs map[string]string
-	var flags []string
-
-	if len(ce.delayedAttributes) > 0 {
-		// we make a deep copy of our attributes and flags
-		copy(flags, ce.flags)
+	mode := attributeOrder(writer)
+	hooks := render.AttributeHooks(writer, ce.tag)
+	if len(ce.delayedAttributes) == 0 && len(hooks) == 0 {
+		// the attributes can not change anymore, so we only format them once per order
+		return writeString(writer, ce.staticAttributes(mode))
+	}
 
-		attrs = make(map[string]string)
-		maps.Copy(attrs, ce.attributes)
+	// we make a deep copy of our attributes and flags
+	attrs := make(map[string]string)
+	maps.Copy(attrs, ce.attributes)
+	flags := slices.Clone(ce.flags)
+	order := slices.Clone(ce.order)
 
-		// and then apply all delayed attributes
-		for _, delayed := range ce.delayedAttributes {
+	// and then apply all delayed attributes
+	for _, delayed := range ce.delayedAttributes {
+		order = track(attrs, &flags, order, func() {
 			delayed(attrs, &flags, nil)
-		}
-	} else {
-		// no copy needed
-		flags = ce.flags
-		attrs = ce.attributes
+		})
 	}
+	return writeAttributes(writer, mode, ce.tag, attrs, flags, order, hooks)
+}
 
-	var allAttrs []string
-	for k, v := range attrs {
-		allAttrs = append(allAttrs, fmt.Sprintf("%s=%q", k, v))
+// writeAttributes applies the hooks to the attributes and flags of an element with the tag, and writes them in
+// the given order. If a snapshot records the start tags of elements with the tag, it records them instead.
+func writeAttributes(writer io.Writer, mode AttributeOrder, tag string, attrs map[string]string, flags []string,
+	order []string, hooks []render.AttributeHook) error {
+	for _, hook := range hooks {
+		var err error
+		order = track(attrs, &flags, order, func() {
+			err = hook(tag, attrs, &flags)
+		})
+		if err != nil {
+			return err
+		}
 	}
-	for _, k := range flags {
-		allAttrs = append(allAttrs, k)
+	if len(hooks) > 0 {
+		// snapshots register a hook for the tags they record, so we only look for them if there are hooks
+		if rec := recorderFrom(writer); rec != nil && rec.records(tag) {
+			return rec.hole(writer, snapshotHole{tag: tag, attrs: attrs, flags: flags, order: order})
+		}
 	}
 
-	// we sort for a stable result
-	slices.Sort(allAttrs)
+	buf := getScratch()
+	defer putScratch(buf)
+	*buf = appendAttributes(*buf, orderAttributes(mode, attrs, flags, order))
+	if len(*buf) == 0 {
+		return nil
+	}
+	_, err := writer.Write(*buf)
+	return err
+}
 
-	if len(allAttrs) > 0 {
-		_, err := writer.Write([]byte(" " + strings.Join(allAttrs, " ")))
-		return err
+// staticAttributes returns the formatted attributes of an element without delayed attributes in the given order.
+func (ce *childlessElement) staticAttributes(mode AttributeOrder) string {
+	if mode < 0 || int(mode) >= len(ce.formatted) {
+		return string(appendAttributes(nil, orderAttributes(mode, ce.attributes, ce.flags, ce.order)))
 	}
-	return nil
+	if formatted := ce.formatted[mode].Load(); formatted != nil {
+		return *formatted
+	}
+	formatted := string(appendAttributes(nil, orderAttributes(mode, ce.attributes, ce.flags, ce.order)))
+	ce.formatted[mode].Store(&formatted)
+	return formatted
+}
+
+// appendAttributes appends the formatted attributes, each preceded by a space, to dst.
+func appendAttributes(dst []byte, parts []attributePart) []byte {
+	for _, part := range parts {
+		dst = append(dst, ' ')
+		dst = append(dst, part.text...)
+	}
+	return dst
 }
 
 // Render writes the complete representation of the element to the provided writer.
 func (ce *childlessElement) Render(writer io.Writer) error {
-	if _, err := writer.Write([]byte(fmt.Sprintf("<%s", ce.tag))); err != nil {
+	if err := openTag(writer, ce.tag); err != nil {
 		return err
 	}
 	if err := ce.renderAttributes(writer); err != nil {
 		return err
 	}
-
-	_, err := writer.Write([]byte("/>"))
-	return err
+	return writeString(writer, "/>")
 }
 
 // element represents a standard HTML element that can have zero or more child elements.
@@ -107,9 +158,7 @@ func NewElement(tag string, attrs ...types.Attribute) types.ElementFactory {
 		},
 		children: nil,
 	}
-	for _, attr := range attrs {
-		attr(e.attributes, &e.flags, &e.delayedAttributes)
-	}
+	e.apply(attrs)
 	return func(children ...types.Element) types.Element {
 		e.children = children
 		return e
@@ -118,13 +167,13 @@ func NewElement(tag string, attrs ...types.Attribute) types.ElementFactory {
 
 // Render writes the complete representation of the element, including its children, to the provided writer.
 func (e *element) Render(writer io.Writer) error {
-	if _, err := writer.Write([]byte(fmt.Sprintf("<%s", e.tag))); err != nil {
+	if err := openTag(writer, e.tag); err != nil {
 		return err
 	}
 	if err := e.renderAttributes(writer); err != nil {
 		return err
 	}
-	if _, err := writer.Write([]byte(">")); err != nil {
+	if err := writeString(writer, ">"); err != nil {
 		return err
 	}
 	for _, child := range e.children {
@@ -132,8 +181,7 @@ func (e *element) Render(writer io.Writer) error {
 			return err
 		}
 	}
-	_, err := writer.Write([]byte(fmt.Sprintf("</%s>", e.tag)))
-	return err
+	return closeTag(writer, e.tag)
 }
 
 // stringElement represents an element that only holds a static string.
@@ -152,17 +200,127 @@ func NewStringElement(content string) types.Element {
 	return &stringElement{data: []byte(content)}
 }
 
+// container represents an element that only holds its children.
+type container struct {
+	children []types.Element
+}
+
+// Render writes all children of the container to the provided writer.
+func (c *container) Render(writer io.Writer) error {
+	for _, child := range c.children {
+		if err := child.Render(writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewContainer creates a new element that renders the children without enclosing tag.
+func NewContainer(children ...types.Element) types.Element {
+	return &container{children: children}
+}
+
+// AddAttributes returns a copy of the element with the attributes applied after its own attributes. If the element
+// is a container, the attributes are added to each of its top-level children. Elements that were not created by this
+// package, like delayed elements, can not be modified and are returned as is.
+// The children of the element are shared with the copy.
+func AddAttributes(el types.Element, attrs ...types.Attribute) types.Element {
+	switch e := el.(type) {
+	case *childlessElement:
+		c := &childlessElement{}
+		e.copyTo(c, attrs)
+		return c
+	case *element:
+		c := &element{children: e.children}
+		e.copyTo(&c.childlessElement, attrs)
+		return c
+	case *container:
+		children := make([]types.Element, len(e.children))
+		for i, child := range e.children {
+			children[i] = AddAttributes(child, attrs...)
+		}
+		return &container{children: children}
+	}
+	return el
+}
+
+// copyTo copies the tag and attributes of the element to dst, and applies the attributes after them.
+func (ce *childlessElement) copyTo(dst *childlessElement, attrs []types.Attribute) {
+	dst.tag = ce.tag
+	dst.attributes = make(map[string]string, len(ce.attributes))
+	maps.Copy(dst.attributes, ce.attributes)
+	dst.flags = slices.Clone(ce.flags)
+	dst.delayedAttributes = slices.Clone(ce.delayedAttributes)
+	dst.order = slices.Clone(ce.order)
+	dst.apply(attrs)
+}
+
+// FormatAttribute formats an attribute as `key="value"`. The value is HTML escaped, so it may contain any character.
+// It is used for the attributes of all elements and by the template package, so every attribute value is rendered
+// with the same escaping. Unlike Go quoting, HTML escaping keeps values with quotes, backslashes or newlines
+// intact, e.g. `"` is rendered as `&#34;` instead of `\"`, which would end the value.
+func FormatAttribute(key, value string) string {
+	return key + `="` + html.EscapeString(value) + `"`
+}
+
 // MultiValueAttribute creates an attribute with a key that can hold multiple space-separated values.
-// If the attribute already exists, the new values are appended.
+// If the attribute already exists, the new values are appended. Values that are already present are skipped,
+// so every value is only contained once, in the order it was first added.
 // Example usage for a class attribute: MultiValueAttribute("class", "btn", "btn-primary")
 func MultiValueAttribute(key string, values ...string) types.Attribute {
 	return func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
-		if cValue, exists := attrs[key]; exists {
-			attrs[key] = strings.Join(append([]string{cValue}, values...), " ")
+		tokens := strings.Fields(attrs[key])
+		for _, value := range values {
+			for _, token := range strings.Fields(value) {
+				if !slices.Contains(tokens, token) {
+					tokens = append(tokens, token)
+				}
+			}
+		}
+		attrs[key] = strings.Join(tokens, " ")
+	}
+}
+
+// RemoveValues creates an attribute that removes the values from the space-separated values of the attribute
+// with the given key. If no value is left, the attribute is removed.
+// Example usage for a class attribute: RemoveValues("class", "btn-primary")
+func RemoveValues(key string, values ...string) types.Attribute {
+	return func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+		current, exists := attrs[key]
+		if !exists {
+			return
+		}
+		tokens := slices.DeleteFunc(strings.Fields(current), func(token string) bool {
+			return slices.Contains(values, token)
+		})
+		if len(tokens) == 0 {
+			delete(attrs, key)
 		} else {
-			attrs[key] = strings.Join(values, " ")
+			attrs[key] = strings.Join(tokens, " ")
+		}
+	}
+}
+
+// ConditionalValues creates an attribute that adds the values to the attribute with the given key,
+// if the condition is true. See MultiValueAttribute.
+func ConditionalValues(key string, condition bool, values ...string) types.Attribute {
+	if !condition {
+		return func(map[string]string, *[]string, *[]types.Attribute) {}
+	}
+	return MultiValueAttribute(key, values...)
+}
+
+// ValueMap creates an attribute that adds all values of the map that are mapped to true to the attribute
+// with the given key. The values are added in sorted order for a stable result. See MultiValueAttribute.
+func ValueMap(key string, values map[string]bool) types.Attribute {
+	var enabled []string
+	for value, condition := range values {
+		if condition {
+			enabled = append(enabled, value)
 		}
 	}
+	slices.Sort(enabled)
+	return ConditionalValues(key, len(enabled) > 0, enabled...)
 }
 
 // SingleAttribute creates an attribute with a single key-value pair.
@@ -186,3 +344,80 @@ func FlagAttribute(flag string) types.Attribute {
 		*flags = append(*flags, flag)
 	}
 }
+
+// RemoveAttribute creates an attribute that removes the attribute and all flags with the given name.
+// Example usage for a wrapper component: RemoveAttribute("disabled")
+func RemoveAttribute(name string) types.Attribute {
+	return func(attrs map[string]string, flags *[]string, _ *[]types.Attribute) {
+		delete(attrs, name)
+		removeFlag(flags, name)
+	}
+}
+
+// OverrideAttribute creates an attribute that sets the attribute to the value, replacing an existing value
+// or flag with the same name instead of panicking like SingleAttribute.
+func OverrideAttribute(key, value string) types.Attribute {
+	return func(attrs map[string]string, flags *[]string, _ *[]types.Attribute) {
+		removeFlag(flags, key)
+		attrs[key] = value
+	}
+}
+
+// ToggleFlag creates an attribute that adds the flag if enabled is true, and removes it otherwise.
+// The flag is only added once, and an attribute with the same name is replaced by the flag.
+func ToggleFlag(flag string, enabled bool) types.Attribute {
+	return func(attrs map[string]string, flags *[]string, _ *[]types.Attribute) {
+		delete(attrs, flag)
+		if !enabled {
+			removeFlag(flags, flag)
+		} else if !slices.Contains(*flags, flag) {
+			*flags = append(*flags, flag)
+		}
+	}
+}
+
+// removeFlag removes all occurrences of the flag.
+func removeFlag(flags *[]string, flag string) {
+	if flags != nil {
+		*flags = slices.DeleteFunc(*flags, func(f string) bool { return f == flag })
+	}
+}
+
+// ValidateAttributeName checks if name is a valid HTML attribute name.
+// A valid name consists of one or more characters other than controls, space, `"`, `'`, `>`, `/`, `=`
+// and Unicode noncharacters.
+func ValidateAttributeName(name string) error {
+	if name == "" {
+		return fmt.Errorf("attribute name must not be empty")
+	}
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("attribute name %q is not valid UTF-8", name)
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsControl(r), r == ' ', r == '"', r == '\'', r == '>', r == '/', r == '=':
+			return fmt.Errorf("attribute name %q contains invalid character %q at position %d", name, r, i)
+		case r >= 0xFDD0 && r <= 0xFDEF, r&0xFFFE == 0xFFFE:
+			return fmt.Errorf("attribute name %q contains noncharacter %U at position %d", name, r, i)
+		}
+	}
+	return nil
+}
+
+// ValidatedAttribute works like SingleAttribute, but panics if key is not a valid HTML attribute name.
+// Use it for attributes with names that are not known in advance.
+func ValidatedAttribute(key, value string) types.Attribute {
+	if err := ValidateAttributeName(key); err != nil {
+		panic(err.Error())
+	}
+	return SingleAttribute(key, value)
+}
+
+// ValidatedFlag works like FlagAttribute, but panics if flag is not a valid HTML attribute name.
+// Use it for flags with names that are not known in advance.
+func ValidatedFlag(flag string) types.Attribute {
+	if err := ValidateAttributeName(flag); err != nil {
+		panic(err.Error())
+	}
+	return FlagAttribute(flag)
+}
@@ -2,11 +2,15 @@ package helpers_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
 )
 
 type HelpersTestSuite struct {
@@ -66,3 +70,152 @@ func (s *HelpersTestSuite) TestAttributes() {
 	assert.NoError(s.T(), divElemWithFlag.Render(&buf))
 	assert.Equal(s.T(), `<div hidden></div>`, buf.String())
 }
+
+func (s *HelpersTestSuite) TestAttributeEscaping() {
+	Div := helpers.NewElement("div", helpers.SingleAttribute("data-json", `{"a":"<b>&'"}`))
+	var buf bytes.Buffer
+	assert.NoError(s.T(), Div().Render(&buf))
+	assert.Equal(s.T(), `<div data-json="{&#34;a&#34;:&#34;&lt;b&gt;&amp;&#39;&#34;}"></div>`, buf.String())
+
+	// backslashes and non-ASCII characters are not escaped
+	buf.Reset()
+	assert.NoError(s.T(), helpers.NewElement("div", helpers.SingleAttribute("title", `C:\temp ü`))().Render(&buf))
+	assert.Equal(s.T(), `<div title="C:\temp ü"></div>`, buf.String())
+}
+
+func (s *HelpersTestSuite) TestValidateAttributeName() {
+	for _, name := range []string{"class", "x-on:click.prevent", "@click", ":class", "x-data", "data-ä", "[hidden]"} {
+		assert.NoError(s.T(), helpers.ValidateAttributeName(name), name)
+	}
+	for _, name := range []string{"", "a b", `a"`, "a'", "a>", "a/", "a=b", "a\x00", "a\n", "a﷐", "a￿", "a\xff"} {
+		assert.Error(s.T(), helpers.ValidateAttributeName(name), name)
+	}
+}
+
+func (s *HelpersTestSuite) TestValidatedAttributes() {
+	Div := helpers.NewElement("div", helpers.ValidatedAttribute("@click", "open = true"), helpers.ValidatedFlag("x-cloak"))
+	var buf bytes.Buffer
+	assert.NoError(s.T(), Div().Render(&buf))
+	assert.Equal(s.T(), `<div @click="open = true" x-cloak></div>`, buf.String())
+
+	assert.Panics(s.T(), func() { helpers.ValidatedAttribute("a=b", "") })
+	assert.Panics(s.T(), func() { helpers.ValidatedFlag("a b") })
+}
+
+func (s *HelpersTestSuite) TestDelayedAttributesKeepFlags() {
+	delayed := func(attrs map[string]string, _ *[]string, delayed *[]types.Attribute) {
+		*delayed = append(*delayed, helpers.SingleAttribute("id", "delayed"))
+	}
+	Div := helpers.NewElement("div", helpers.FlagAttribute("hidden"), delayed)
+	var buf bytes.Buffer
+	assert.NoError(s.T(), Div().Render(&buf))
+	assert.Equal(s.T(), `<div hidden id="delayed"></div>`, buf.String())
+}
+
+func (s *HelpersTestSuite) TestAttributeHooks() {
+	hook := func(tag string, attrs map[string]string, flags *[]string) error {
+		if tag == "span" {
+			return errors.New("span not allowed")
+		}
+		attrs["data-tag"] = tag
+		*flags = append(*flags, "hooked")
+		return nil
+	}
+	ctx := render.WithAttributeHook(context.Background(), hook)
+
+	Div := helpers.NewElement("div", helpers.SingleAttribute("id", "test"))
+	div := Div(helpers.NewChildlessElement("br"))
+	var buf bytes.Buffer
+	assert.NoError(s.T(), div.Render(render.WithContext(&buf, ctx)))
+	assert.Equal(s.T(), `<div data-tag="div" hooked id="test"><br data-tag="br" hooked/></div>`, buf.String())
+
+	// hooks only modify the current render
+	buf.Reset()
+	assert.NoError(s.T(), div.Render(&buf))
+	assert.Equal(s.T(), `<div id="test"><br/></div>`, buf.String())
+
+	assert.Error(s.T(), helpers.NewElement("span")().Render(render.WithContext(&buf, ctx)))
+}
+
+func (s *HelpersTestSuite) TestAttributeHookTags() {
+	calls := 0
+	ctx := render.WithAttributeHook(context.Background(), func(tag string, attrs map[string]string, _ *[]string) error {
+		calls++
+		attrs["nonce"] = "abc"
+		return nil
+	}, "script", "style")
+
+	div := helpers.NewElement("div", helpers.SingleAttribute("id", "test"))(
+		helpers.NewElement("script")(),
+		helpers.NewChildlessElement("br"),
+	)
+	var buf bytes.Buffer
+	assert.NoError(s.T(), div.Render(render.WithContext(&buf, ctx)))
+	assert.Equal(s.T(), `<div id="test"><script nonce="abc"></script><br/></div>`, buf.String())
+	assert.Equal(s.T(), 1, calls, "the hook is only called for its tags")
+
+	tags, all := render.AttributeHookTags(render.WithContext(&buf, ctx))
+	assert.Equal(s.T(), []string{"script", "style"}, tags)
+	assert.False(s.T(), all)
+	assert.Empty(s.T(), render.AttributeHooks(render.WithContext(&buf, ctx), "div"))
+}
+
+func (s *HelpersTestSuite) TestValueLists() {
+	attrs := make(map[string]string)
+	apply := func(attributes ...types.Attribute) {
+		for _, attr := range attributes {
+			attr(attrs, nil, nil)
+		}
+	}
+
+	apply(
+		helpers.MultiValueAttribute("sandbox", "allow-forms", "allow-scripts"),
+		helpers.MultiValueAttribute("sandbox", "allow-scripts allow-popups"),
+		helpers.ConditionalValues("sandbox", false, "allow-same-origin"),
+		helpers.ConditionalValues("headers", true, "name", "name"),
+		helpers.ValueMap("headers", map[string]bool{"email": true, "phone": false}),
+	)
+	assert.Equal(s.T(), map[string]string{
+		"sandbox": "allow-forms allow-scripts allow-popups",
+		"headers": "name email",
+	}, attrs)
+
+	apply(
+		helpers.RemoveValues("sandbox", "allow-scripts"),
+		helpers.RemoveValues("headers", "name", "email"),
+		helpers.RemoveValues("rel", "preload"),
+	)
+	assert.Equal(s.T(), map[string]string{"sandbox": "allow-forms allow-popups"}, attrs)
+}
+
+func (s *HelpersTestSuite) TestModifiers() {
+	Input := helpers.NewChildlessElement("input",
+		helpers.SingleAttribute("type", "text"),
+		helpers.FlagAttribute("required"),
+		helpers.FlagAttribute("disabled"),
+		helpers.OverrideAttribute("type", "email"),
+		helpers.RemoveAttribute("required"),
+		helpers.ToggleFlag("disabled", false),
+		helpers.ToggleFlag("readonly", true),
+		helpers.ToggleFlag("readonly", true),
+	)
+	var buf bytes.Buffer
+	assert.NoError(s.T(), Input.Render(&buf))
+	assert.Equal(s.T(), `<input readonly type="email"/>`, buf.String())
+}
+
+func (s *HelpersTestSuite) TestAddAttributes() {
+	Div := helpers.NewElement("div", helpers.SingleAttribute("id", "a"))
+	original := Div(helpers.NewChildlessElement("br"))
+	group := helpers.NewContainer(original, helpers.NewChildlessElement("hr"), helpers.NewStringElement("text"))
+	added := helpers.AddAttributes(group, helpers.FlagAttribute("hidden"))
+
+	var buf bytes.Buffer
+	assert.NoError(s.T(), added.Render(&buf))
+	assert.Equal(s.T(), `<div hidden id="a"><br/></div><hr hidden/>text`, buf.String())
+
+	// the original elements are not modified
+	buf.Reset()
+	assert.NoError(s.T(), group.Render(&buf))
+	assert.Equal(s.T(), `<div id="a"><br/></div><hr/>text`, buf.String())
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package helpers_test
+
+// raceEnabled is true if the race detector is enabled.
+const raceEnabled = false
@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"slices"
+	"sync/atomic"
+
+	"github.com/tbe/godom/render"
+)
+
+// AttributeOrder defines the order in which the attributes and flags of an element are rendered.
+type AttributeOrder int32
+
+const (
+	// SortedOrder renders the attributes and flags sorted by their names. This is the default.
+	SortedOrder AttributeOrder = iota
+	// InsertionOrder renders the attributes and flags in the order they were first added to the element.
+	InsertionOrder
+	// CanonicalOrder renders the id first, followed by the class, followed by all other attributes and flags
+	// sorted by their names.
+	CanonicalOrder
+)
+
+// defaultOrder is the AttributeOrder used if the render does not define one.
+var defaultOrder atomic.Int32
+
+// SetAttributeOrder sets the AttributeOrder of all renders that do not define one with WithAttributeOrder.
+func SetAttributeOrder(order AttributeOrder) {
+	defaultOrder.Store(int32(order))
+}
+
+// orderKey is the context key for the AttributeOrder of a render.
+type orderKey struct{}
+
+// WithAttributeOrder returns a copy of ctx that defines the AttributeOrder of the render.
+// Use render.WithContext to render elements with the returned context.
+func WithAttributeOrder(ctx context.Context, order AttributeOrder) context.Context {
+	return context.WithValue(ctx, orderKey{}, order)
+}
+
+// attributeOrder returns the AttributeOrder of the render of writer.
+func attributeOrder(writer io.Writer) AttributeOrder {
+	if order, ok := render.Context(writer).Value(orderKey{}).(AttributeOrder); ok {
+		return order
+	}
+	return AttributeOrder(defaultOrder.Load())
+}
+
+// track records the names of the attributes and flags added by apply in the insertion order.
+// As the attributes are a map, attributes added by a single call are recorded in sorted order.
+func track(attrs map[string]string, flags *[]string, order []string, apply func()) []string {
+	count, flagCount := len(attrs), len(*flags)
+	apply()
+
+	if len(attrs) > count {
+		var added []string
+		for name := range attrs {
+			if !slices.Contains(order, name) {
+				added = append(added, name)
+			}
+		}
+		slices.Sort(added)
+		order = append(order, added...)
+	}
+	if len(*flags) > flagCount {
+		for _, flag := range (*flags)[flagCount:] {
+			if !slices.Contains(order, flag) {
+				order = append(order, flag)
+			}
+		}
+	}
+	return order
+}
+
+// attributePart is a formatted attribute or flag.
+type attributePart struct {
+	name string
+	text string
+}
+
+// orderAttributes returns the formatted attributes and flags in the given order.
+func orderAttributes(mode AttributeOrder, attrs map[string]string, flags []string, order []string) []attributePart {
+	parts := make([]attributePart, 0, len(attrs)+len(flags))
+	for name, value := range attrs {
+		parts = append(parts, attributePart{name: name, text: FormatAttribute(name, value)})
+	}
+	for _, flag := range flags {
+		parts = append(parts, attributePart{name: flag, text: flag})
+	}
+
+	var rank func(name string) int
+	switch mode {
+	case InsertionOrder:
+		rank = func(name string) int {
+			// names that were not tracked, e.g. if an attribute was replaced by another, are added last
+			if i := slices.Index(order, name); i >= 0 {
+				return i
+			}
+			return len(order)
+		}
+	case CanonicalOrder:
+		rank = func(name string) int {
+			switch name {
+			case "id":
+				return 0
+			case "class":
+				return 1
+			}
+			return 2
+		}
+	default:
+		rank = func(string) int { return 0 }
+	}
+
+	slices.SortFunc(parts, func(a, b attributePart) int {
+		if c := cmp.Compare(rank(a.name), rank(b.name)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		// an attribute and a flag with the same name are ordered by their text for a stable result
+		return cmp.Compare(a.text, b.text)
+	})
+	return parts
+}
@@ -0,0 +1,62 @@
+package helpers_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+func (s *HelpersTestSuite) renderOrdered(order helpers.AttributeOrder, element types.Element) string {
+	var buf bytes.Buffer
+	ctx := helpers.WithAttributeOrder(context.Background(), order)
+	assert.NoError(s.T(), element.Render(render.WithContext(&buf, ctx)))
+	return buf.String()
+}
+
+func (s *HelpersTestSuite) TestAttributeOrder() {
+	delayed := func(_ map[string]string, _ *[]string, delayed *[]types.Attribute) {
+		*delayed = append(*delayed, helpers.SingleAttribute("data-delayed", "1"))
+	}
+	input := helpers.NewChildlessElement("input",
+		helpers.SingleAttribute("type", "text"),
+		helpers.SingleAttribute("data-x-y", "2"),
+		helpers.FlagAttribute("required"),
+		helpers.MultiValueAttribute("class", "a"),
+		helpers.SingleAttribute("data-x", "1"),
+		delayed,
+		helpers.SingleAttribute("id", "name"),
+		helpers.MultiValueAttribute("class", "b"),
+	)
+
+	assert.Equal(s.T(),
+		`<input class="a b" data-delayed="1" data-x="1" data-x-y="2" id="name" required type="text"/>`,
+		s.renderOrdered(helpers.SortedOrder, input),
+	)
+	assert.Equal(s.T(),
+		`<input type="text" data-x-y="2" required class="a b" data-x="1" id="name" data-delayed="1"/>`,
+		s.renderOrdered(helpers.InsertionOrder, input),
+	)
+	assert.Equal(s.T(),
+		`<input id="name" class="a b" data-delayed="1" data-x="1" data-x-y="2" required type="text"/>`,
+		s.renderOrdered(helpers.CanonicalOrder, input),
+	)
+}
+
+func (s *HelpersTestSuite) TestDefaultAttributeOrder() {
+	defer helpers.SetAttributeOrder(helpers.SortedOrder)
+
+	helpers.SetAttributeOrder(helpers.CanonicalOrder)
+	var buf bytes.Buffer
+	assert.NoError(s.T(), helpers.NewElement("div", helpers.SingleAttribute("title", "x"), helpers.SingleAttribute("id", "y"))().Render(&buf))
+	assert.Equal(s.T(), `<div id="y" title="x"></div>`, buf.String())
+
+	// the order of the render takes precedence
+	assert.Equal(s.T(),
+		`<div title="x" id="y"></div>`,
+		s.renderOrdered(helpers.InsertionOrder, helpers.NewElement("div", helpers.SingleAttribute("title", "x"), helpers.SingleAttribute("id", "y"))()),
+	)
+}
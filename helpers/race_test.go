@@ -0,0 +1,7 @@
+//go:build race
+
+package helpers_test
+
+// raceEnabled is true if the race detector is enabled. The race detector randomly drops the buffers of a
+// sync.Pool, so the allocations of a render can not be tested.
+const raceEnabled = true
@@ -0,0 +1,238 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+	"golang.org/x/exp/maps"
+)
+
+// ErrInvalidSnapshot is returned by Snapshot.UnmarshalBinary if the data is not an encoded Snapshot.
+var ErrInvalidSnapshot = errors.New("helpers: invalid snapshot")
+
+// holeMarkerPrefix starts the marker of a hole in the output recorded by a snapshot. It is followed by the
+// random token of the snapshot, the decimal index of the hole and holeMarkerEnd.
+const (
+	holeMarkerPrefix = "\x00godom-hole-"
+	holeMarkerEnd    = '\x00'
+)
+
+// Snapshot is the rendered output of an element, in which the attributes of the elements that attribute hooks
+// (see render.WithAttributeHook) apply to are left open. Rendering the snapshot writes the output and applies the
+// hooks of the current render to the open attributes, so the output can be reused by renders with different
+// hooks, like the nonces of the csp package.
+type Snapshot struct {
+	// chunks holds the output before each hole, followed by the output after the last hole
+	chunks [][]byte
+	holes  []snapshotHole
+	// tags holds the tags of the elements with open attributes, all is true if the attributes of all elements
+	// are open
+	tags []string
+	all  bool
+	// mode is the AttributeOrder of the render the snapshot was taken by
+	mode AttributeOrder
+}
+
+// snapshotHole holds the attributes of an element, after its delayed attributes were applied.
+type snapshotHole struct {
+	tag   string
+	attrs map[string]string
+	flags []string
+	order []string
+}
+
+// snapshotKey is the context key of the recorder of a snapshot.
+type snapshotKey struct{}
+
+// recorder records the holes of a snapshot during its render.
+type recorder struct {
+	marker []byte
+	tags   []string
+	all    bool
+
+	mu    sync.Mutex
+	holes []snapshotHole
+}
+
+// recorderFrom returns the recorder of the snapshot rendered into writer, or nil if there is none.
+func recorderFrom(writer io.Writer) *recorder {
+	rec, _ := render.Context(writer).Value(snapshotKey{}).(*recorder)
+	return rec
+}
+
+// records reports whether the attributes of elements with the tag are left open.
+func (r *recorder) records(tag string) bool {
+	return r.all || slices.Contains(r.tags, tag)
+}
+
+// hole records the attributes and writes the marker of the hole in their place. The output of an element may be
+// rendered into an intermediate buffer before it is written, so the position of the hole is only known by the
+// marker. The marker contains a random token, so it can not be forged by user content.
+func (r *recorder) hole(writer io.Writer, hole snapshotHole) error {
+	r.mu.Lock()
+	n := len(r.holes)
+	r.holes = append(r.holes, hole)
+	r.mu.Unlock()
+
+	marker := strconv.AppendInt(append([]byte(nil), r.marker...), int64(n), 10)
+	_, err := writer.Write(append(marker, holeMarkerEnd))
+	return err
+}
+
+// TakeSnapshot renders the element into a Snapshot. The attributes of elements with the tags of the attribute
+// hooks of writer are left open, all other output is fixed. Hooks added within the element are applied while the
+// snapshot is taken. Nothing is written to writer.
+//
+// The element is rendered detached from render.Document (see render.Detach), as the snapshot may be rendered by
+// other renders.
+func TakeSnapshot(writer io.Writer, element types.Element) (*Snapshot, error) {
+	tags, all := render.AttributeHookTags(writer)
+	ctx := render.Detach(render.Context(writer))
+
+	var buf bytes.Buffer
+	if !all && len(tags) == 0 {
+		if err := element.Render(render.WithContext(&buf, ctx)); err != nil {
+			return nil, err
+		}
+		return &Snapshot{chunks: [][]byte{buf.Bytes()}, mode: attributeOrder(writer)}, nil
+	}
+
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return nil, err
+	}
+	rec := &recorder{
+		marker: []byte(holeMarkerPrefix + hex.EncodeToString(token[:]) + "-"),
+		tags:   slices.Clone(tags),
+		all:    all,
+	}
+	// the hooks of the render are applied when the snapshot is rendered, the recorder only registers a hook, so
+	// the elements it records are not rendered with their static attributes
+	ctx = context.WithValue(render.WithoutAttributeHooks(ctx), snapshotKey{}, rec)
+	ctx = render.WithAttributeHook(ctx, func(string, map[string]string, *[]string) error { return nil }, rec.tags...)
+	if err := element.Render(render.WithContext(&buf, ctx)); err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{tags: rec.tags, all: all, mode: attributeOrder(writer)}
+	data := buf.Bytes()
+	for {
+		idx := bytes.Index(data, rec.marker)
+		if idx < 0 {
+			break
+		}
+		rest := data[idx+len(rec.marker):]
+		length := bytes.IndexByte(rest, holeMarkerEnd)
+		if length < 0 {
+			return nil, fmt.Errorf("unterminated hole marker")
+		}
+		n, err := strconv.Atoi(string(rest[:length]))
+		if err != nil || n < 0 || n >= len(rec.holes) {
+			return nil, fmt.Errorf("invalid hole marker %q", rest[:length])
+		}
+		s.chunks = append(s.chunks, data[:idx])
+		s.holes = append(s.holes, rec.holes[n])
+		data = rest[length+1:]
+	}
+	s.chunks = append(s.chunks, data)
+	return s, nil
+}
+
+// Covers reports whether the snapshot was taken with the AttributeOrder of writer, and leaves the attributes of all
+// elements open that the attribute hooks of writer apply to, so rendering it is the same as rendering the element
+// it was taken from.
+func (s *Snapshot) Covers(writer io.Writer) bool {
+	if attributeOrder(writer) != s.mode {
+		return false
+	}
+	if s.all {
+		return true
+	}
+	tags, all := render.AttributeHookTags(writer)
+	if all {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(s.tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Render writes the output of the snapshot, and applies the attribute hooks of writer to the open attributes.
+func (s *Snapshot) Render(writer io.Writer) error {
+	mode := attributeOrder(writer)
+	for i, hole := range s.holes {
+		if _, err := writer.Write(s.chunks[i]); err != nil {
+			return err
+		}
+		// hooks modify the attributes, so every render works on a copy
+		attrs := make(map[string]string, len(hole.attrs))
+		maps.Copy(attrs, hole.attrs)
+		err := writeAttributes(writer, mode, hole.tag, attrs, slices.Clone(hole.flags), slices.Clone(hole.order),
+			render.AttributeHooks(writer, hole.tag))
+		if err != nil {
+			return err
+		}
+	}
+	_, err := writer.Write(s.chunks[len(s.chunks)-1])
+	return err
+}
+
+// snapshotData is the encoded form of a Snapshot.
+type snapshotData struct {
+	Chunks [][]byte
+	Holes  []holeData
+	Tags   []string
+	All    bool
+	Mode   AttributeOrder
+}
+
+// holeData is the encoded form of a snapshotHole.
+type holeData struct {
+	Tag   string
+	Attrs map[string]string
+	Flags []string
+	Order []string
+}
+
+// MarshalBinary encodes the snapshot, e.g. to store it in a cache.
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	data := snapshotData{Chunks: s.chunks, Tags: s.tags, All: s.all, Mode: s.mode}
+	for _, hole := range s.holes {
+		data.Holes = append(data.Holes, holeData{Tag: hole.tag, Attrs: hole.attrs, Flags: hole.flags, Order: hole.order})
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a snapshot encoded by MarshalBinary.
+func (s *Snapshot) UnmarshalBinary(encoded []byte) error {
+	var data snapshotData
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&data); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if len(data.Chunks) != len(data.Holes)+1 {
+		return ErrInvalidSnapshot
+	}
+	*s = Snapshot{chunks: data.Chunks, tags: data.Tags, all: data.All, mode: data.Mode}
+	for _, hole := range data.Holes {
+		s.holes = append(s.holes, snapshotHole{tag: hole.Tag, attrs: hole.Attrs, flags: hole.Flags, order: hole.Order})
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package helpers_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+)
+
+func (s *HelpersTestSuite) TestSnapshot() {
+	nonce := func(value string) context.Context {
+		return render.WithAttributeHook(context.Background(), func(_ string, attrs map[string]string, _ *[]string) error {
+			attrs["nonce"] = value
+			return nil
+		}, "script")
+	}
+	element := helpers.NewElement("div", helpers.SingleAttribute("id", "x"))(
+		helpers.NewElement("script", helpers.SingleAttribute("src", "app.js"), helpers.FlagAttribute("defer"))(),
+		helpers.NewStringElement("text"),
+	)
+
+	var buf bytes.Buffer
+	snapshot, err := helpers.TakeSnapshot(render.WithContext(&buf, nonce("a")), element)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), buf.String(), "taking a snapshot writes nothing")
+
+	data, err := snapshot.MarshalBinary()
+	assert.NoError(s.T(), err)
+	var decoded helpers.Snapshot
+	assert.NoError(s.T(), decoded.UnmarshalBinary(data))
+
+	for _, value := range []string{"a", "b"} {
+		writer := render.WithContext(&buf, nonce(value))
+		assert.True(s.T(), decoded.Covers(writer))
+		buf.Reset()
+		assert.NoError(s.T(), decoded.Render(writer))
+		assert.Equal(s.T(), `<div id="x"><script defer nonce="`+value+`" src="app.js"></script>text</div>`, buf.String())
+	}
+
+	// hooks for other tags are not covered
+	ctx := render.WithAttributeHook(context.Background(), func(string, map[string]string, *[]string) error {
+		return nil
+	}, "div")
+	assert.False(s.T(), decoded.Covers(render.WithContext(&buf, ctx)))
+
+	assert.ErrorIs(s.T(), decoded.UnmarshalBinary([]byte("<div></div>")), helpers.ErrInvalidSnapshot)
+	assert.ErrorIs(s.T(), decoded.UnmarshalBinary(data[:len(data)-1]), helpers.ErrInvalidSnapshot)
+}
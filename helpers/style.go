@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/tbe/godom/types"
+)
+
+// StyleDeclaration is a declaration of the style attribute, like `color:red`.
+type StyleDeclaration struct {
+	Property string
+	Value    string
+}
+
+// String returns the declaration in CSS syntax.
+func (d StyleDeclaration) String() string {
+	return d.Property + ":" + d.Value
+}
+
+// StyleAttribute creates the style attribute with the given value. If the element already has a style attribute,
+// the declarations of the value are merged into it, and declarations of the same property replace the earlier ones.
+func StyleAttribute(style string) types.Attribute {
+	return func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+		existing, exists := attrs["style"]
+		if !exists || existing == "" {
+			attrs["style"] = style
+			return
+		}
+		attrs["style"] = MergeStyle(existing, ParseStyle(style)...)
+	}
+}
+
+// MergeStyle adds the declarations to the declarations of the style attribute value, and returns the merged value.
+// Declarations of the same property replace the earlier ones.
+func MergeStyle(style string, declarations ...StyleDeclaration) string {
+	merged := ParseStyle(style)
+	for _, d := range declarations {
+		result := merged[:0]
+		for _, existing := range merged {
+			if existing.Property != d.Property {
+				result = append(result, existing)
+			}
+		}
+		merged = append(result, d)
+	}
+	return FormatStyle(merged)
+}
+
+// FormatStyle returns the declarations in the syntax of the style attribute.
+func FormatStyle(declarations []StyleDeclaration) string {
+	parts := make([]string, len(declarations))
+	for i, d := range declarations {
+		parts[i] = d.String()
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseStyle splits the value of a style attribute into its declarations. Semicolons inside of strings
+// and parentheses do not end a declaration. Invalid declarations are dropped.
+func ParseStyle(style string) []StyleDeclaration {
+	var declarations []StyleDeclaration
+	for _, part := range splitStyle(style, ';') {
+		parts := splitStyle(part, ':')
+		if len(parts) < 2 {
+			continue
+		}
+		property := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(part[len(parts[0])+1:])
+		if property == "" || value == "" {
+			continue
+		}
+		declarations = append(declarations, StyleDeclaration{Property: property, Value: value})
+	}
+	return declarations
+}
+
+// splitStyle splits s at every occurrence of sep outside of strings and parentheses.
+func splitStyle(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,20 @@
+package helpers_test
+
+import (
+	"bytes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom/helpers"
+)
+
+func (s *HelpersTestSuite) TestStyleAttribute() {
+	div := helpers.NewElement("div",
+		helpers.StyleAttribute("color: red; margin: url('a;b')"),
+		helpers.StyleAttribute("color: blue"),
+	)()
+	var buf bytes.Buffer
+	assert.NoError(s.T(), div.Render(&buf))
+	assert.Equal(s.T(), `<div style="margin:url(&#39;a;b&#39;);color:blue"></div>`, buf.String())
+
+	assert.Equal(s.T(), "a:1;b:3", helpers.MergeStyle("a: 1; b: 2", helpers.StyleDeclaration{Property: "b", Value: "3"}))
+}
@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"io"
+	"sync"
+)
+
+// scratchPool holds the scratch buffers used to write strings and attributes without allocating.
+var scratchPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 256)
+		return &buf
+	},
+}
+
+// maxScratchSize is the capacity above which a scratch buffer is not returned to the pool, so a single huge
+// write does not keep its memory alive.
+const maxScratchSize = 64 << 10
+
+// getScratch returns an empty scratch buffer from the pool.
+func getScratch() *[]byte {
+	buf := scratchPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// putScratch returns the scratch buffer to the pool.
+func putScratch(buf *[]byte) {
+	if cap(*buf) <= maxScratchSize {
+		scratchPool.Put(buf)
+	}
+}
+
+// writeString writes s to w. Unlike io.WriteString, it does not allocate if w does not implement io.StringWriter,
+// but copies s into a scratch buffer.
+func writeString(w io.Writer, s string) error {
+	if s == "" {
+		return nil
+	}
+	if sw, ok := w.(io.StringWriter); ok {
+		_, err := sw.WriteString(s)
+		return err
+	}
+	buf := getScratch()
+	*buf = append(*buf, s...)
+	_, err := w.Write(*buf)
+	putScratch(buf)
+	return err
+}
+
+// openTag writes the beginning of the start tag of the element, e.g. `<div`.
+func openTag(w io.Writer, tag string) error {
+	if err := writeString(w, "<"); err != nil {
+		return err
+	}
+	return writeString(w, tag)
+}
+
+// closeTag writes the end tag of the element, e.g. `</div>`.
+func closeTag(w io.Writer, tag string) error {
+	if err := writeString(w, "</"); err != nil {
+		return err
+	}
+	if err := writeString(w, tag); err != nil {
+		return err
+	}
+	return writeString(w, ">")
+}
@@ -0,0 +1,72 @@
+package helpers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// writerOnly hides all methods of the writer except Write.
+type writerOnly struct {
+	io.Writer
+}
+
+// staticTree returns a tree without delayed attributes or elements.
+func staticTree() types.Element {
+	return helpers.NewContainer(
+		helpers.NewStringElement("<!DOCTYPE html>"),
+		helpers.NewElement("html", helpers.SingleAttribute("lang", "en"))(
+			helpers.NewElement("body", helpers.MultiValueAttribute("class", "page dark"))(
+				helpers.NewElement("div", helpers.SingleAttribute("id", "main"), helpers.SingleAttribute("title", `"quoted"`))(
+					helpers.NewChildlessElement("input", helpers.SingleAttribute("type", "text"), helpers.FlagAttribute("required")),
+					helpers.NewElement("p")(helpers.NewStringElement("Hello &amp; welcome")),
+				),
+			),
+		),
+	)
+}
+
+func (s *HelpersTestSuite) TestRenderAllocs() {
+	if raceEnabled {
+		s.T().Skip("sync.Pool drops buffers with the race detector")
+	}
+	tree := staticTree()
+	expected := `<!DOCTYPE html><html lang="en"><body class="page dark"><div id="main" title="&#34;quoted&#34;">` +
+		`<input required type="text"/><p>Hello &amp; welcome</p></div></body></html>`
+
+	var buf bytes.Buffer
+	buf.Grow(1024)
+	ordered := render.WithContext(&buf, helpers.WithAttributeOrder(context.Background(), helpers.CanonicalOrder))
+
+	writers := map[string]io.Writer{
+		"buffer":       &buf,
+		"write only":   writerOnly{&buf},
+		"with context": ordered,
+	}
+	for name, writer := range writers {
+		buf.Reset()
+		assert.NoError(s.T(), tree.Render(writer))
+		assert.Equal(s.T(), expected, buf.String(), name)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			buf.Reset()
+			_ = tree.Render(writer)
+		})
+		assert.Zero(s.T(), allocs, name)
+	}
+
+	compiled := helpers.Compile(tree)
+	assert.Zero(s.T(), testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		_ = compiled.Render(&buf)
+	}))
+	assert.Zero(s.T(), testing.AllocsPerRun(100, func() {
+		_ = tree.Render(io.Discard)
+	}))
+}
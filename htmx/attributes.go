@@ -0,0 +1,318 @@
+/*
+Package htmx provides typed attributes and server helpers for htmx (https://htmx.org).
+
+The attributes cover the hx-* attributes of htmx, with typed values where htmx expects a specific syntax:
+
+	Button(htmx.HxPost("/like"), htmx.HxSwap(htmx.OuterHTML, htmx.Settle(200*time.Millisecond)))(Content("Like"))
+	Input(htmx.HxGet("/search"), htmx.HxTrigger(htmx.Trigger("keyup").Changed().Delay(500*time.Millisecond)))
+
+On the server side, IsRequest detects htmx requests, and Fragments renders only the named fragments of a full
+document, so the same element tree can serve both full page loads and partial updates.
+*/
+package htmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// The HxGet attribute issues a GET request to the given URL.
+func HxGet(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-get", url)
+}
+
+// The HxPost attribute issues a POST request to the given URL.
+func HxPost(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-post", url)
+}
+
+// The HxPut attribute issues a PUT request to the given URL.
+func HxPut(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-put", url)
+}
+
+// The HxPatch attribute issues a PATCH request to the given URL.
+func HxPatch(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-patch", url)
+}
+
+// The HxDelete attribute issues a DELETE request to the given URL.
+func HxDelete(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-delete", url)
+}
+
+// The HxTarget attribute specifies the target element for swapping, using an extended CSS selector.
+func HxTarget(selector string) types.Attribute {
+	return helpers.SingleAttribute("hx-target", selector)
+}
+
+// The HxSelect attribute selects the content to swap in from the response, using a CSS selector.
+func HxSelect(selector string) types.Attribute {
+	return helpers.SingleAttribute("hx-select", selector)
+}
+
+// The HxSelectOOB attribute selects content from the response to swap in out-of-band.
+func HxSelectOOB(selectors ...string) types.Attribute {
+	return helpers.SingleAttribute("hx-select-oob", strings.Join(selectors, ","))
+}
+
+// The HxSwapOOB attribute marks an element in a response to be swapped in out-of-band.
+// The value is either "true", a swap style, or a swap style followed by a selector (e.g. "afterbegin:#list").
+func HxSwapOOB(value string) types.Attribute {
+	return helpers.SingleAttribute("hx-swap-oob", value)
+}
+
+// The HxPushURL attribute pushes the given URL into the browser history. Use "true" to push the request URL.
+func HxPushURL(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-push-url", url)
+}
+
+// The HxReplaceURL attribute replaces the current URL in the browser history. Use "true" to use the request URL.
+func HxReplaceURL(url string) types.Attribute {
+	return helpers.SingleAttribute("hx-replace-url", url)
+}
+
+// The HxConfirm attribute shows a confirm dialog with the given message before issuing a request.
+func HxConfirm(message string) types.Attribute {
+	return helpers.SingleAttribute("hx-confirm", message)
+}
+
+// The HxPrompt attribute shows a prompt with the given message before issuing a request.
+func HxPrompt(message string) types.Attribute {
+	return helpers.SingleAttribute("hx-prompt", message)
+}
+
+// The HxIndicator attribute specifies the element that gets the htmx-request class during a request.
+func HxIndicator(selector string) types.Attribute {
+	return helpers.SingleAttribute("hx-indicator", selector)
+}
+
+// The HxInclude attribute includes the values of additional elements in the request.
+func HxInclude(selector string) types.Attribute {
+	return helpers.SingleAttribute("hx-include", selector)
+}
+
+// The HxParams attribute filters the parameters that are submitted with a request.
+func HxParams(params string) types.Attribute {
+	return helpers.SingleAttribute("hx-params", params)
+}
+
+// The HxSync attribute synchronizes the requests of multiple elements, e.g. HxSync("closest form", "abort").
+func HxSync(selector, strategy string) types.Attribute {
+	if strategy == "" {
+		return helpers.SingleAttribute("hx-sync", selector)
+	}
+	return helpers.SingleAttribute("hx-sync", selector+":"+strategy)
+}
+
+// The HxBoost attribute enables or disables boosting of links and forms.
+func HxBoost(boost bool) types.Attribute {
+	return helpers.SingleAttribute("hx-boost", fmt.Sprint(boost))
+}
+
+// The HxDisabledElt attribute specifies the elements that are disabled during a request.
+func HxDisabledElt(selector string) types.Attribute {
+	return helpers.SingleAttribute("hx-disabled-elt", selector)
+}
+
+// The HxEncoding attribute changes the request encoding, e.g. to "multipart/form-data".
+func HxEncoding(encoding string) types.Attribute {
+	return helpers.SingleAttribute("hx-encoding", encoding)
+}
+
+// The HxExt attribute enables htmx extensions for an element and its children.
+func HxExt(extensions ...string) types.Attribute {
+	return helpers.SingleAttribute("hx-ext", strings.Join(extensions, ","))
+}
+
+// The HxOn attribute handles an event with an inline script, e.g. HxOn("htmx:after-request", "this.reset()").
+func HxOn(event, script string) types.Attribute {
+	return helpers.SingleAttribute("hx-on:"+event, script)
+}
+
+// The HxPreserve flag keeps an element unchanged between requests. The element requires an ID.
+func HxPreserve() types.Attribute {
+	return helpers.FlagAttribute("hx-preserve")
+}
+
+// The HxVals attribute adds the given values to the parameters of a request. The values are encoded as JSON.
+// It panics if the values can not be encoded.
+func HxVals(values map[string]any) types.Attribute {
+	return helpers.SingleAttribute("hx-vals", mustMarshal("hx-vals", values))
+}
+
+// The HxHeaders attribute adds the given headers to a request. The headers are encoded as JSON.
+func HxHeaders(headers map[string]string) types.Attribute {
+	return helpers.SingleAttribute("hx-headers", mustMarshal("hx-headers", headers))
+}
+
+// mustMarshal encodes v as JSON, and panics if that fails.
+func mustMarshal(attr string, v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode %s attribute: %v", attr, err))
+	}
+	return string(data)
+}
+
+// SwapStyle specifies how the response content is swapped into the target.
+type SwapStyle string
+
+// The swap styles supported by htmx.
+const (
+	InnerHTML   SwapStyle = "innerHTML"
+	OuterHTML   SwapStyle = "outerHTML"
+	TextContent SwapStyle = "textContent"
+	BeforeBegin SwapStyle = "beforebegin"
+	AfterBegin  SwapStyle = "afterbegin"
+	BeforeEnd   SwapStyle = "beforeend"
+	AfterEnd    SwapStyle = "afterend"
+	Delete      SwapStyle = "delete"
+	None        SwapStyle = "none"
+)
+
+// SwapModifier modifies the behaviour of a swap. See HxSwap.
+type SwapModifier string
+
+// Swap delays the swap by the given duration.
+func Swap(delay time.Duration) SwapModifier {
+	return SwapModifier("swap:" + formatDuration(delay))
+}
+
+// Settle delays the settling after the swap by the given duration.
+func Settle(delay time.Duration) SwapModifier {
+	return SwapModifier("settle:" + formatDuration(delay))
+}
+
+// Transition uses the View Transitions API for the swap.
+func Transition() SwapModifier {
+	return "transition:true"
+}
+
+// IgnoreTitle ignores any title tag in the response.
+func IgnoreTitle() SwapModifier {
+	return "ignoreTitle:true"
+}
+
+// Scroll scrolls the target (or the element matched by the optional selector) to the given position ("top" or "bottom").
+func Scroll(position string, selector ...string) SwapModifier {
+	return SwapModifier("scroll:" + strings.Join(append(selector, position), ":"))
+}
+
+// Show scrolls the target (or the element matched by the optional selector) into view at the given position.
+func Show(position string, selector ...string) SwapModifier {
+	return SwapModifier("show:" + strings.Join(append(selector, position), ":"))
+}
+
+// FocusScroll specifies whether a focused element is scrolled into view after the swap.
+func FocusScroll(scroll bool) SwapModifier {
+	return SwapModifier(fmt.Sprintf("focus-scroll:%t", scroll))
+}
+
+// The HxSwap attribute specifies how the response content is swapped into the target.
+func HxSwap(style SwapStyle, modifiers ...SwapModifier) types.Attribute {
+	parts := []string{string(style)}
+	for _, m := range modifiers {
+		parts = append(parts, string(m))
+	}
+	return helpers.SingleAttribute("hx-swap", strings.Join(parts, " "))
+}
+
+// TriggerSpec describes an event that triggers a request. It is created with Trigger or Every.
+type TriggerSpec struct {
+	event     string
+	filter    string
+	modifiers []string
+}
+
+// Trigger creates a new TriggerSpec for the given event.
+func Trigger(event string) *TriggerSpec {
+	return &TriggerSpec{event: event}
+}
+
+// Every creates a TriggerSpec that polls in the given interval.
+func Every(interval time.Duration) *TriggerSpec {
+	return &TriggerSpec{event: "every " + formatDuration(interval)}
+}
+
+// Filter only triggers if the given JavaScript expression is true, e.g. Trigger("click").Filter("ctrlKey").
+func (t *TriggerSpec) Filter(expression string) *TriggerSpec {
+	t.filter = expression
+	return t
+}
+
+// Once only triggers once.
+func (t *TriggerSpec) Once() *TriggerSpec {
+	return t.modifier("once")
+}
+
+// Changed only triggers if the value of the element has changed.
+func (t *TriggerSpec) Changed() *TriggerSpec {
+	return t.modifier("changed")
+}
+
+// Delay waits for the given duration before triggering. The delay is reset by new events.
+func (t *TriggerSpec) Delay(delay time.Duration) *TriggerSpec {
+	return t.modifier("delay:" + formatDuration(delay))
+}
+
+// Throttle triggers at most once in the given interval.
+func (t *TriggerSpec) Throttle(interval time.Duration) *TriggerSpec {
+	return t.modifier("throttle:" + formatDuration(interval))
+}
+
+// From listens for the event on the elements matched by the extended CSS selector.
+func (t *TriggerSpec) From(selector string) *TriggerSpec {
+	return t.modifier("from:" + selector)
+}
+
+// Target only triggers if the target of the event matches the CSS selector.
+func (t *TriggerSpec) Target(selector string) *TriggerSpec {
+	return t.modifier("target:" + selector)
+}
+
+// Consume prevents the event from triggering requests on parent elements.
+func (t *TriggerSpec) Consume() *TriggerSpec {
+	return t.modifier("consume")
+}
+
+// Queue specifies which events are queued while a request is in flight ("first", "last", "all" or "none").
+func (t *TriggerSpec) Queue(queue string) *TriggerSpec {
+	return t.modifier("queue:" + queue)
+}
+
+func (t *TriggerSpec) modifier(modifier string) *TriggerSpec {
+	t.modifiers = append(t.modifiers, modifier)
+	return t
+}
+
+// String returns the trigger in the syntax of the hx-trigger attribute.
+func (t *TriggerSpec) String() string {
+	event := t.event
+	if t.filter != "" {
+		event += "[" + t.filter + "]"
+	}
+	return strings.Join(append([]string{event}, t.modifiers...), " ")
+}
+
+// The HxTrigger attribute specifies the events that trigger a request.
+func HxTrigger(triggers ...*TriggerSpec) types.Attribute {
+	specs := make([]string, len(triggers))
+	for i, t := range triggers {
+		specs[i] = t.String()
+	}
+	return helpers.SingleAttribute("hx-trigger", strings.Join(specs, ", "))
+}
+
+// formatDuration formats a duration in the time syntax of htmx.
+func formatDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	return fmt.Sprintf("%dms", d/time.Millisecond)
+}
@@ -0,0 +1,93 @@
+package htmx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tbe/godom/htmx"
+	"github.com/tbe/godom/types"
+)
+
+func TestAttributes(t *testing.T) {
+	suite.Run(t, new(AttributesTestSuite))
+}
+
+type AttributesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AttributesTestSuite) testAttr(attribute types.Attribute, key, value string) {
+	attrs := make(map[string]string)
+	var flags []string
+	attribute(attrs, &flags, nil)
+
+	suite.Equal(map[string]string{key: value}, attrs)
+	suite.Empty(flags)
+}
+
+func (suite *AttributesTestSuite) TestRequests() {
+	suite.testAttr(htmx.HxGet("/a"), "hx-get", "/a")
+	suite.testAttr(htmx.HxPost("/a"), "hx-post", "/a")
+	suite.testAttr(htmx.HxPut("/a"), "hx-put", "/a")
+	suite.testAttr(htmx.HxPatch("/a"), "hx-patch", "/a")
+	suite.testAttr(htmx.HxDelete("/a"), "hx-delete", "/a")
+}
+
+func (suite *AttributesTestSuite) TestSimpleAttributes() {
+	suite.testAttr(htmx.HxTarget("#list"), "hx-target", "#list")
+	suite.testAttr(htmx.HxSelect("#list"), "hx-select", "#list")
+	suite.testAttr(htmx.HxSelectOOB("#a", "#b"), "hx-select-oob", "#a,#b")
+	suite.testAttr(htmx.HxSwapOOB("true"), "hx-swap-oob", "true")
+	suite.testAttr(htmx.HxPushURL("true"), "hx-push-url", "true")
+	suite.testAttr(htmx.HxReplaceURL("/b"), "hx-replace-url", "/b")
+	suite.testAttr(htmx.HxConfirm("Sure?"), "hx-confirm", "Sure?")
+	suite.testAttr(htmx.HxPrompt("Name"), "hx-prompt", "Name")
+	suite.testAttr(htmx.HxIndicator("#spinner"), "hx-indicator", "#spinner")
+	suite.testAttr(htmx.HxInclude("[name=q]"), "hx-include", "[name=q]")
+	suite.testAttr(htmx.HxParams("*"), "hx-params", "*")
+	suite.testAttr(htmx.HxSync("closest form", "abort"), "hx-sync", "closest form:abort")
+	suite.testAttr(htmx.HxSync("this", ""), "hx-sync", "this")
+	suite.testAttr(htmx.HxBoost(true), "hx-boost", "true")
+	suite.testAttr(htmx.HxDisabledElt("this"), "hx-disabled-elt", "this")
+	suite.testAttr(htmx.HxEncoding("multipart/form-data"), "hx-encoding", "multipart/form-data")
+	suite.testAttr(htmx.HxExt("json-enc", "loading-states"), "hx-ext", "json-enc,loading-states")
+	suite.testAttr(htmx.HxOn("htmx:after-request", "this.reset()"), "hx-on:htmx:after-request", "this.reset()")
+}
+
+func (suite *AttributesTestSuite) TestPreserve() {
+	var flags []string
+	htmx.HxPreserve()(nil, &flags, nil)
+	suite.Equal([]string{"hx-preserve"}, flags)
+}
+
+func (suite *AttributesTestSuite) TestVals() {
+	suite.testAttr(htmx.HxVals(map[string]any{"id": 42, "name": "test"}), "hx-vals", `{"id":42,"name":"test"}`)
+	suite.testAttr(htmx.HxHeaders(map[string]string{"X-Token": "abc"}), "hx-headers", `{"X-Token":"abc"}`)
+	suite.Panics(func() { htmx.HxVals(map[string]any{"invalid": make(chan int)}) })
+}
+
+func (suite *AttributesTestSuite) TestSwap() {
+	suite.testAttr(htmx.HxSwap(htmx.InnerHTML), "hx-swap", "innerHTML")
+	suite.testAttr(
+		htmx.HxSwap(htmx.OuterHTML, htmx.Swap(time.Second), htmx.Settle(200*time.Millisecond), htmx.Transition()),
+		"hx-swap", "outerHTML swap:1s settle:200ms transition:true",
+	)
+	suite.testAttr(
+		htmx.HxSwap(htmx.BeforeEnd, htmx.Scroll("bottom"), htmx.Show("top", "#list"), htmx.FocusScroll(false), htmx.IgnoreTitle()),
+		"hx-swap", "beforeend scroll:bottom show:#list:top focus-scroll:false ignoreTitle:true",
+	)
+}
+
+func (suite *AttributesTestSuite) TestTrigger() {
+	suite.testAttr(htmx.HxTrigger(htmx.Trigger("click")), "hx-trigger", "click")
+	suite.testAttr(
+		htmx.HxTrigger(htmx.Trigger("keyup").Changed().Delay(500*time.Millisecond), htmx.Trigger("search")),
+		"hx-trigger", "keyup changed delay:500ms, search",
+	)
+	suite.testAttr(
+		htmx.HxTrigger(htmx.Trigger("click").Filter("ctrlKey").Once().Consume().Queue("last").From("body").Target("#a")),
+		"hx-trigger", "click[ctrlKey] once consume queue:last from:body target:#a",
+	)
+	suite.testAttr(htmx.HxTrigger(htmx.Every(2*time.Second).Throttle(time.Second)), "hx-trigger", "every 2s throttle:1s")
+}
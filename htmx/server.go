@@ -0,0 +1,203 @@
+package htmx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// The request headers sent by htmx.
+const (
+	HeaderRequest     = "HX-Request"
+	HeaderBoosted     = "HX-Boosted"
+	HeaderTarget      = "HX-Target"
+	HeaderTrigger     = "HX-Trigger"
+	HeaderTriggerName = "HX-Trigger-Name"
+	HeaderCurrentURL  = "HX-Current-URL"
+)
+
+// The response headers evaluated by htmx.
+const (
+	HeaderLocation   = "HX-Location"
+	HeaderPushURL    = "HX-Push-Url"
+	HeaderRedirect   = "HX-Redirect"
+	HeaderRefresh    = "HX-Refresh"
+	HeaderReplaceURL = "HX-Replace-Url"
+	HeaderReswap     = "HX-Reswap"
+	HeaderRetarget   = "HX-Retarget"
+	HeaderReselect   = "HX-Reselect"
+)
+
+// IsRequest reports whether the request was issued by htmx.
+func IsRequest(r *http.Request) bool {
+	return r.Header.Get(HeaderRequest) == "true"
+}
+
+// IsBoosted reports whether the request was issued by a boosted link or form.
+func IsBoosted(r *http.Request) bool {
+	return r.Header.Get(HeaderBoosted) == "true"
+}
+
+// Target returns the ID of the target element of the request, if it has one.
+func Target(r *http.Request) string {
+	return r.Header.Get(HeaderTarget)
+}
+
+// TriggerID returns the ID of the element that triggered the request, if it has one.
+func TriggerID(r *http.Request) string {
+	return r.Header.Get(HeaderTrigger)
+}
+
+// TriggerName returns the name of the element that triggered the request, if it has one.
+func TriggerName(r *http.Request) string {
+	return r.Header.Get(HeaderTriggerName)
+}
+
+// CurrentURL returns the current URL of the browser.
+func CurrentURL(r *http.Request) string {
+	return r.Header.Get(HeaderCurrentURL)
+}
+
+// TriggerEvents sets the HX-Trigger response header, which triggers the given events on the client.
+func TriggerEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set(HeaderTrigger, strings.Join(events, ", "))
+}
+
+// Retarget sets the HX-Retarget response header, which overrides the target of the swap.
+func Retarget(w http.ResponseWriter, selector string) {
+	w.Header().Set(HeaderRetarget, selector)
+}
+
+// Reswap sets the HX-Reswap response header, which overrides the swap style.
+func Reswap(w http.ResponseWriter, style SwapStyle, modifiers ...SwapModifier) {
+	parts := []string{string(style)}
+	for _, m := range modifiers {
+		parts = append(parts, string(m))
+	}
+	w.Header().Set(HeaderReswap, strings.Join(parts, " "))
+}
+
+// Redirect sets the HX-Redirect response header, which performs a full client side redirect.
+func Redirect(w http.ResponseWriter, url string) {
+	w.Header().Set(HeaderRedirect, url)
+}
+
+// Refresh sets the HX-Refresh response header, which performs a full page refresh.
+func Refresh(w http.ResponseWriter) {
+	w.Header().Set(HeaderRefresh, "true")
+}
+
+// fragmentKey is the context key for the fragment selection.
+type fragmentKey struct{}
+
+// selection collects the output of the selected fragments.
+type selection struct {
+	mu      sync.Mutex
+	outputs map[string]*bytes.Buffer
+	found   map[string]bool
+	// oob holds the fragments that are swapped in out-of-band
+	oob map[string]bool
+}
+
+// Fragment marks the given element as a named fragment. Rendered normally, the element is rendered as is.
+// Rendered by Fragments, only selected fragments are part of the output.
+func Fragment(name string, element types.Element) types.Element {
+	return &fragmentElement{name: name, element: element, oob: helpers.AddAttributes(element, swapOOB)}
+}
+
+type fragmentElement struct {
+	name    string
+	element types.Element
+	// oob is the element with the hx-swap-oob attribute on its top-level elements
+	oob types.Element
+}
+
+func (f *fragmentElement) Render(writer io.Writer) error {
+	ctx := render.Context(writer)
+	sel, _ := ctx.Value(fragmentKey{}).(*selection)
+	if sel == nil {
+		return f.element.Render(writer)
+	}
+	out, selected := sel.outputs[f.name]
+	if !selected {
+		return f.element.Render(writer)
+	}
+
+	// the selected fragment is rendered as a whole, including all nested fragments
+	ctx = context.WithValue(ctx, fragmentKey{}, nil)
+	element := f.element
+	if sel.oob[f.name] {
+		element = f.oob
+	}
+	var buf bytes.Buffer
+	if err := element.Render(render.WithContext(&buf, ctx)); err != nil {
+		return err
+	}
+
+	sel.mu.Lock()
+	defer sel.mu.Unlock()
+	sel.found[f.name] = true
+	_, err := buf.WriteTo(out)
+	return err
+}
+
+// Fragments returns an element that renders only the named fragments (see Fragment) of the given element,
+// in the order of the names. All other output of the element is discarded.
+//
+// This allows to answer htmx requests with a part of the full document. The first fragment is the content for
+// the target, while the following fragments are swapped in out-of-band: their top-level elements get the
+// hx-swap-oob="true" attribute, unless they already have an hx-swap-oob attribute (see HxSwapOOB). As the attribute
+// is only added to the partial response, the fragments are not swapped out-of-band when the full document is
+// rendered, e.g. for boosted requests. The attribute can only be added to elements built with the helpers package,
+// and to the children of groups of them. Other elements, like delayed elements, must set HxSwapOOB themselves.
+// Rendering fails if one of the fragments is not part of the element.
+func Fragments(element types.Element, names ...string) types.Element {
+	return &fragmentsElement{element: element, names: names}
+}
+
+type fragmentsElement struct {
+	element types.Element
+	names   []string
+}
+
+func (f *fragmentsElement) Render(writer io.Writer) error {
+	sel := &selection{
+		outputs: make(map[string]*bytes.Buffer),
+		found:   make(map[string]bool),
+		oob:     make(map[string]bool),
+	}
+	for i, name := range f.names {
+		sel.outputs[name] = new(bytes.Buffer)
+		sel.oob[name] = i > 0
+	}
+
+	ctx := context.WithValue(render.Context(writer), fragmentKey{}, sel)
+	if err := f.element.Render(render.WithContext(io.Discard, ctx)); err != nil {
+		return err
+	}
+
+	for _, name := range f.names {
+		if !sel.found[name] {
+			return fmt.Errorf("fragment %q not found", name)
+		}
+		if _, err := sel.outputs[name].WriteTo(writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swapOOB adds hx-swap-oob="true", unless the element already has an hx-swap-oob attribute.
+func swapOOB(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+	if _, exists := attrs["hx-swap-oob"]; !exists {
+		attrs["hx-swap-oob"] = "true"
+	}
+}
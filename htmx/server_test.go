@@ -0,0 +1,114 @@
+package htmx_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/htmx"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+type ServerTestSuite struct {
+	suite.Suite
+	buf bytes.Buffer
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	suite.buf.Reset()
+}
+
+func (suite *ServerTestSuite) TestRequestHeaders() {
+	r := httptest.NewRequest("GET", "/", nil)
+	suite.False(htmx.IsRequest(r))
+	suite.False(htmx.IsBoosted(r))
+
+	r.Header.Set(htmx.HeaderRequest, "true")
+	r.Header.Set(htmx.HeaderBoosted, "true")
+	r.Header.Set(htmx.HeaderTarget, "list")
+	r.Header.Set(htmx.HeaderTrigger, "button")
+	r.Header.Set(htmx.HeaderTriggerName, "save")
+	r.Header.Set(htmx.HeaderCurrentURL, "http://example.com/")
+	suite.True(htmx.IsRequest(r))
+	suite.True(htmx.IsBoosted(r))
+	suite.Equal("list", htmx.Target(r))
+	suite.Equal("button", htmx.TriggerID(r))
+	suite.Equal("save", htmx.TriggerName(r))
+	suite.Equal("http://example.com/", htmx.CurrentURL(r))
+}
+
+func (suite *ServerTestSuite) TestResponseHeaders() {
+	w := httptest.NewRecorder()
+	htmx.TriggerEvents(w, "saved", "refresh")
+	htmx.Retarget(w, "#errors")
+	htmx.Reswap(w, htmx.InnerHTML, htmx.Settle(0))
+	htmx.Redirect(w, "/login")
+	htmx.Refresh(w)
+
+	suite.Equal("saved, refresh", w.Header().Get(htmx.HeaderTrigger))
+	suite.Equal("#errors", w.Header().Get(htmx.HeaderRetarget))
+	suite.Equal("innerHTML settle:0s", w.Header().Get(htmx.HeaderReswap))
+	suite.Equal("/login", w.Header().Get(htmx.HeaderRedirect))
+	suite.Equal("true", w.Header().Get(htmx.HeaderRefresh))
+}
+
+func (suite *ServerTestSuite) page() types.Element {
+	return Body()(
+		H1()(Content("Title")),
+		htmx.Fragment("list", UL(ID("list"))(
+			Li()(Content("first")),
+			htmx.Fragment("item", Li()(Content("second"))),
+		)),
+		htmx.Fragment("counter", Span(ID("counter"))(Content("2"))),
+		htmx.Fragment("notice", P(ID("notice"), htmx.HxSwapOOB("afterbegin"))(Content("saved"))),
+	)
+}
+
+func (suite *ServerTestSuite) TestFullRender() {
+	suite.NoError(suite.page().Render(&suite.buf))
+	suite.Equal(`<body><h1>Title</h1><ul id="list"><li>first</li><li>second</li></ul><span id="counter">2</span><p hx-swap-oob="afterbegin" id="notice">saved</p></body>`, suite.buf.String())
+}
+
+func (suite *ServerTestSuite) TestFragments() {
+	suite.NoError(htmx.Fragments(suite.page(), "item").Render(&suite.buf))
+	suite.Equal(`<li>second</li>`, suite.buf.String())
+
+	// fragments are rendered in the order of their names, nested fragments are part of their parents,
+	// and all fragments after the first are swapped in out-of-band
+	suite.buf.Reset()
+	suite.NoError(htmx.Fragments(suite.page(), "list", "counter").Render(&suite.buf))
+	suite.Equal(`<ul id="list"><li>first</li><li>second</li></ul><span hx-swap-oob="true" id="counter">2</span>`, suite.buf.String())
+
+	suite.buf.Reset()
+	suite.NoError(htmx.Fragments(suite.page(), "counter", "item", "notice").Render(&suite.buf))
+	suite.Equal(`<span id="counter">2</span><li hx-swap-oob="true">second</li><p hx-swap-oob="afterbegin" id="notice">saved</p>`, suite.buf.String())
+}
+
+func (suite *ServerTestSuite) TestParallelFragment() {
+	page := Body()(
+		htmx.Fragment("list", UL(ID("list"))()),
+		htmx.Fragment("stats", Group(
+			Div(ID("1"))(util.Parallel(Span(ID("2"))(), Span(ID("3"))(), Span(ID("4"))())),
+			Div(ID("5"))(),
+		)),
+	)
+
+	// only the top-level elements of the out-of-band fragment get the attribute, however its children are rendered
+	for i := 0; i < 20; i++ {
+		suite.buf.Reset()
+		suite.NoError(htmx.Fragments(page, "list", "stats").Render(&suite.buf))
+		suite.Equal(`<ul id="list"></ul><div hx-swap-oob="true" id="1"><span id="2"></span><span id="3"></span>`+
+			`<span id="4"></span></div><div hx-swap-oob="true" id="5"></div>`, suite.buf.String())
+	}
+}
+
+func (suite *ServerTestSuite) TestMissingFragment() {
+	suite.Error(htmx.Fragments(suite.page(), "missing").Render(&suite.buf))
+}
@@ -0,0 +1,104 @@
+package httpdom
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// Versioned is implemented by elements that provide a cheap version key for their output.
+// The version must change whenever the rendered output changes.
+type Versioned interface {
+	types.Element
+	Version() string
+}
+
+// WithVersion returns an element that renders the given element, and reports the given version key.
+// WriteETag uses the version to answer conditional requests without rendering the element.
+func WithVersion(version string, element types.Element) Versioned {
+	return &versionedElement{Element: element, version: version}
+}
+
+type versionedElement struct {
+	types.Element
+	version string
+}
+
+func (v *versionedElement) Version() string {
+	return v.version
+}
+
+// WriteETag works like Write with http.StatusOK, but additionally sets a strong ETag header and honors the
+// If-None-Match header of the request with a 304 (Not Modified) response.
+//
+// The ETag is the hash of the rendered output, computed while the output is written to the buffer. If the element
+// implements Versioned, the ETag is derived from the version key instead, and the element is only rendered if the
+// client does not have the current version. If element is nil, ErrNoElement is returned.
+func WriteETag(w http.ResponseWriter, r *http.Request, element types.Element) error {
+	if element == nil {
+		return ErrNoElement
+	}
+	var buf bytes.Buffer
+	var etag string
+
+	if versioned, ok := element.(Versioned); ok {
+		etag = versionETag(versioned.Version())
+		if notModified(w, r, etag) {
+			return nil
+		}
+		if err := render.Document(render.WithContext(&buf, r.Context()), element); err != nil {
+			return err
+		}
+	} else {
+		hash := sha256.New()
+		if err := render.Document(render.WithContext(io.MultiWriter(&buf, hash), r.Context()), element); err != nil {
+			return err
+		}
+		etag = strconv.Quote(hex.EncodeToString(hash.Sum(nil)[:16]))
+		if notModified(w, r, etag) {
+			return nil
+		}
+	}
+
+	w.Header().Set("ETag", etag)
+	writeBuffer(w, r, http.StatusOK, &buf)
+	return nil
+}
+
+// versionETag returns the ETag for a version key. It is prefixed, so it never collides with the hash of an output.
+func versionETag(version string) string {
+	hash := sha256.Sum256([]byte(version))
+	return strconv.Quote("v-" + hex.EncodeToString(hash[:16]))
+}
+
+// notModified writes a 304 (Not Modified) response, if the If-None-Match header of the request matches the etag.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports if the If-None-Match header value matches the etag.
+// As specified for If-None-Match, the weak comparison is used.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
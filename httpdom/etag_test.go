@@ -0,0 +1,106 @@
+package httpdom_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/httpdom"
+	"github.com/tbe/godom/types"
+)
+
+type ETagTestSuite struct {
+	suite.Suite
+}
+
+// TestETagTestSuite initializes the test suite.
+func TestETagTestSuite(t *testing.T) {
+	suite.Run(t, new(ETagTestSuite))
+}
+
+// countingElement counts how often it was rendered.
+type countingElement struct {
+	renders int
+}
+
+func (c *countingElement) Render(writer io.Writer) error {
+	c.renders++
+	_, err := writer.Write([]byte("<p>counted</p>"))
+	return err
+}
+
+func (s *ETagTestSuite) serve(element types.Element, method, ifNoneMatch string) *httptest.ResponseRecorder {
+	handler := &httpdom.Handler{
+		Func: func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+			return element, nil
+		},
+		ETag: true,
+	}
+
+	req := httptest.NewRequest(method, "/", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *ETagTestSuite) TestETag() {
+	element := P()(Content("Hello"))
+
+	rec := s.serve(element, http.MethodGet, "")
+	etag := rec.Header().Get("ETag")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Regexp(`^"[0-9a-f]{32}"$`, etag)
+	s.Equal("<p>Hello</p>", rec.Body.String())
+
+	// the same output results in the same etag
+	s.Equal(etag, s.serve(P()(Content("Hello")), http.MethodGet, "").Header().Get("ETag"))
+	// but a different output does not
+	s.NotEqual(etag, s.serve(P()(Content("World")), http.MethodGet, "").Header().Get("ETag"))
+}
+
+func (s *ETagTestSuite) TestNotModified() {
+	element := P()(Content("Hello"))
+	etag := s.serve(element, http.MethodGet, "").Header().Get("ETag")
+
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec := s.serve(element, http.MethodGet, ifNoneMatch)
+		s.Equal(http.StatusNotModified, rec.Code, ifNoneMatch)
+		s.Equal(etag, rec.Header().Get("ETag"))
+		s.Empty(rec.Body.String())
+	}
+
+	rec := s.serve(element, http.MethodGet, `"other"`)
+	s.Equal(http.StatusOK, rec.Code)
+
+	// only GET and HEAD requests are answered with 304
+	rec = s.serve(element, http.MethodPost, etag)
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ETagTestSuite) TestVersioned() {
+	counter := &countingElement{}
+	element := httpdom.WithVersion("v1", counter)
+
+	rec := s.serve(element, http.MethodGet, "")
+	etag := rec.Header().Get("ETag")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("<p>counted</p>", rec.Body.String())
+	s.Equal(1, counter.renders)
+
+	// a matching version skips the rendering entirely
+	rec = s.serve(element, http.MethodGet, etag)
+	s.Equal(http.StatusNotModified, rec.Code)
+	s.Equal(1, counter.renders)
+
+	// a new version is rendered again
+	rec = s.serve(httpdom.WithVersion("v2", counter), http.MethodGet, etag)
+	s.Equal(http.StatusOK, rec.Code)
+	s.NotEqual(etag, rec.Header().Get("ETag"))
+	s.Equal(2, counter.renders)
+}
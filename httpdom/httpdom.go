@@ -0,0 +1,195 @@
+/*
+Package httpdom provides net/http integration for godom elements.
+
+It takes care of the glue that is needed to serve godom documents: the output is rendered into a buffer first,
+so that rendering errors result in a proper error response instead of a half-written page, the Content-Type and
+Content-Length headers are set, and HEAD requests are answered without a body.
+
+A handler only has to construct the document:
+
+	http.Handle("/", httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		article, err := loadArticle(r)
+		if err != nil {
+			return nil, httpdom.Error(http.StatusNotFound, err)
+		}
+		return articlePage(article), nil
+	}))
+*/
+package httpdom
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/stream"
+	"github.com/tbe/godom/types"
+)
+
+// ContentType is the Content-Type header value that is set for rendered documents, if the handler did not set one.
+const ContentType = "text/html; charset=utf-8"
+
+// ErrNoElement is returned if there is no element to render, e.g. if a HandlerFunc returns neither an element
+// nor an error.
+var ErrNoElement = errors.New("httpdom: no element to render")
+
+// errorHeaders are the headers set by a HandlerFunc that do not apply to the error page.
+var errorHeaders = []string{"Content-Type", "Content-Length", "Cache-Control", "Expires", "ETag", "Last-Modified"}
+
+// HandlerFunc is a function that returns the element to render for a request.
+// The function may set response headers using the provided http.ResponseWriter, but must not write the body.
+// If an error is returned, an error page is rendered instead. The status of the error page can be set by
+// returning a StatusError.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) (types.Element, error)
+
+// ServeHTTP implements http.Handler, using the DefaultErrorPage for errors.
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(&Handler{Func: fn}).ServeHTTP(w, r)
+}
+
+// ErrorPageFunc is a function that returns the element to render for an error response.
+type ErrorPageFunc func(r *http.Request, status int, err error) types.Element
+
+// Handler is a http.Handler that renders the element returned by Func.
+type Handler struct {
+	// Func returns the element to render.
+	Func HandlerFunc
+	// ErrorPage returns the element to render if Func or the rendering fails. If it is nil, DefaultErrorPage is used.
+	ErrorPage ErrorPageFunc
+	// ETag enables ETag and conditional request support for successful responses (see WriteETag).
+	ETag bool
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	element, err := h.Func(w, r)
+	if err == nil {
+		if h.ETag {
+			err = WriteETag(w, r, element)
+		} else {
+			err = Write(w, r, http.StatusOK, element)
+		}
+		if err == nil {
+			return
+		}
+	}
+	h.writeError(w, r, err)
+}
+
+// writeError writes the error page for the given error.
+// If the error page can not be rendered, a plain text error is written.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	// the headers describe the response Func intended to write, not the error page
+	for _, name := range errorHeaders {
+		w.Header().Del(name)
+	}
+
+	status := http.StatusInternalServerError
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		status = statusErr.Code
+	}
+
+	errorPage := h.ErrorPage
+	if errorPage == nil {
+		errorPage = DefaultErrorPage
+	}
+	if Write(w, r, status, errorPage(r, status, err)) != nil {
+		http.Error(w, http.StatusText(status), status)
+	}
+}
+
+// Write renders the element with render.Document into a buffer and writes it as the response with the given status.
+// If rendering fails, the error is returned and nothing is written, so the caller can still write an error response.
+// For HEAD requests, only the headers are written. If element is nil, ErrNoElement is returned.
+func Write(w http.ResponseWriter, r *http.Request, status int, element types.Element) error {
+	if element == nil {
+		return ErrNoElement
+	}
+	var buf bytes.Buffer
+	if err := render.Document(render.WithContext(&buf, r.Context()), element); err != nil {
+		return err
+	}
+	writeBuffer(w, r, status, &buf)
+	return nil
+}
+
+// writeBuffer writes the rendered buffer as the response with the given status.
+func writeBuffer(w http.ResponseWriter, r *http.Request, status int, buf *bytes.Buffer) {
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", ContentType)
+	}
+	header.Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(status)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+	// the status is already sent, so there is nothing left to do on write errors
+	_, _ = buf.WriteTo(w)
+}
+
+// Stream renders the element directly to the response using stream.Render, flushing the output at the boundaries
+// marked by the elements of the stream package. As the response is not buffered, rendering errors can not be turned
+// into an error page once the first byte was flushed. The error is returned to the caller instead.
+// As the document is not rendered with render.Document, the entries of the head package can not be used, and
+// fail the render with head.ErrStreaming.
+// For HEAD requests, only the headers are written.
+func Stream(w http.ResponseWriter, r *http.Request, element types.Element) error {
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", ContentType)
+	}
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	return stream.Render(render.WithContext(w, r.Context()), element)
+}
+
+// StatusError is an error that specifies the HTTP status of the error response.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+// Error creates a new StatusError with the given HTTP status code, wrapping err.
+func Error(code int, err error) error {
+	return &StatusError{Code: code, Err: err}
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%d %s", e.Code, http.StatusText(e.Code))
+	}
+	return fmt.Sprintf("%d %s: %v", e.Code, http.StatusText(e.Code), e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultErrorPage renders a minimal HTML document with the status code and text.
+// The error itself is not included, to avoid leaking internal details to the client.
+func DefaultErrorPage(_ *http.Request, status int, _ error) types.Element {
+	text := fmt.Sprintf("%d %s", status, http.StatusText(status))
+	return godom.Group(
+		godom.Doctype(),
+		godom.HTML()(
+			godom.Head()(
+				godom.Meta(godom.Charset("utf-8")),
+				godom.Title()(godom.Content(text)),
+			),
+			godom.Body()(
+				godom.H1()(godom.Content(text)),
+			),
+		),
+	)
+}
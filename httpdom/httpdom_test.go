@@ -0,0 +1,179 @@
+package httpdom_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/httpdom"
+	"github.com/tbe/godom/stream"
+	"github.com/tbe/godom/types"
+)
+
+type HTTPDomTestSuite struct {
+	suite.Suite
+}
+
+// TestHTTPDomTestSuite initializes the test suite.
+func TestHTTPDomTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPDomTestSuite))
+}
+
+// failingElement is an element that writes some output, and then fails.
+type failingElement struct{}
+
+func (failingElement) Render(writer io.Writer) error {
+	if _, err := writer.Write([]byte("<p>half")); err != nil {
+		return err
+	}
+	return errors.New("render failed")
+}
+
+func (s *HTTPDomTestSuite) serve(handler http.Handler, method string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, "/", nil))
+	return rec
+}
+
+func (s *HTTPDomTestSuite) TestHandlerFunc() {
+	handler := httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		return P()(Content("Hello")), nil
+	})
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(httpdom.ContentType, rec.Header().Get("Content-Type"))
+	s.Equal("12", rec.Header().Get("Content-Length"))
+	s.Equal("<p>Hello</p>", rec.Body.String())
+}
+
+func (s *HTTPDomTestSuite) TestCustomContentType() {
+	handler := httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		w.Header().Set("Content-Type", "application/xhtml+xml")
+		return P()(), nil
+	})
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal("application/xhtml+xml", rec.Header().Get("Content-Type"))
+}
+
+func (s *HTTPDomTestSuite) TestHead() {
+	handler := httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		return P()(Content("Hello")), nil
+	})
+
+	rec := s.serve(handler, http.MethodHead)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("12", rec.Header().Get("Content-Length"))
+	s.Empty(rec.Body.String())
+}
+
+func (s *HTTPDomTestSuite) TestRenderError() {
+	handler := httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		return failingElement{}, nil
+	})
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.NotContains(rec.Body.String(), "half")
+	s.Contains(rec.Body.String(), "<h1>500 Internal Server Error</h1>")
+}
+
+func (s *HTTPDomTestSuite) TestStatusError() {
+	handler := httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		return nil, httpdom.Error(http.StatusNotFound, errors.New("no such article"))
+	})
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Contains(rec.Body.String(), "<h1>404 Not Found</h1>")
+	s.NotContains(rec.Body.String(), "no such article")
+}
+
+func (s *HTTPDomTestSuite) TestCustomErrorPage() {
+	handler := &httpdom.Handler{
+		Func: func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+			return nil, errors.New("failed")
+		},
+		ErrorPage: func(r *http.Request, status int, err error) types.Element {
+			return P()(Content(err.Error()))
+		},
+	}
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal("<p>failed</p>", rec.Body.String())
+}
+
+func (s *HTTPDomTestSuite) TestFailingErrorPage() {
+	handler := &httpdom.Handler{
+		Func: func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+			return nil, errors.New("failed")
+		},
+		ErrorPage: func(r *http.Request, status int, err error) types.Element {
+			return failingElement{}
+		},
+	}
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal("Internal Server Error\n", rec.Body.String())
+}
+
+func (s *HTTPDomTestSuite) TestErrorHeaders() {
+	handler := &httpdom.Handler{
+		Func: func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+			w.Header().Set("Content-Type", "application/xhtml+xml")
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Last-Modified", "Mon, 19 Oct 2026 00:00:00 GMT")
+			w.Header().Set("X-Request-Id", "1")
+			return failingElement{}, nil
+		},
+		ETag: true,
+	}
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal(httpdom.ContentType, rec.Header().Get("Content-Type"))
+	s.Empty(rec.Header().Get("Cache-Control"))
+	s.Empty(rec.Header().Get("ETag"))
+	s.Empty(rec.Header().Get("Last-Modified"))
+	s.Equal("1", rec.Header().Get("X-Request-Id"), "unrelated headers are kept")
+}
+
+func (s *HTTPDomTestSuite) TestNoElement() {
+	handler := httpdom.HandlerFunc(func(w http.ResponseWriter, r *http.Request) (types.Element, error) {
+		return nil, nil
+	})
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Contains(rec.Body.String(), "<h1>500 Internal Server Error</h1>")
+}
+
+func (s *HTTPDomTestSuite) TestStatusErrorMessage() {
+	err := httpdom.Error(http.StatusNotFound, errors.New("missing"))
+	s.Equal("404 Not Found: missing", err.Error())
+	s.Equal("404 Not Found", httpdom.Error(http.StatusNotFound, nil).Error())
+}
+
+func (s *HTTPDomTestSuite) TestStream() {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.NoError(httpdom.Stream(w, r, Body()(P()(Content("first")), stream.Flush(), P()(Content("second")))))
+	})
+
+	rec := s.serve(handler, http.MethodGet)
+	s.Equal(http.StatusOK, rec.Code)
+	s.True(rec.Flushed)
+	s.Equal(httpdom.ContentType, rec.Header().Get("Content-Type"))
+	s.Equal("<body><p>first</p><p>second</p></body>", rec.Body.String())
+
+	rec = s.serve(handler, http.MethodHead)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Empty(rec.Body.String())
+}
@@ -0,0 +1,36 @@
+package godom
+
+import (
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// The Remove attribute removes the attribute or flag with the given name from an Element, e.g. an attribute
+// passed down by the caller of a wrapper component. It only affects the attributes before it.
+//
+// This is a global types.Attribute.
+func Remove(name string) types.Attribute {
+	return helpers.RemoveAttribute(name)
+}
+
+// The Override attribute sets the attribute with the given name, replacing the value set by the attributes before it.
+// It panics if the name is not a valid attribute name.
+//
+// This is a global types.Attribute.
+func Override(name, value string) types.Attribute {
+	if err := helpers.ValidateAttributeName(name); err != nil {
+		panic(err.Error())
+	}
+	return helpers.OverrideAttribute(name, value)
+}
+
+// The Toggle attribute adds the flag with the given name if enabled is true, and removes it otherwise,
+// e.g. Toggle("disabled", isDisabled). It panics if the name is not a valid attribute name.
+//
+// This is a global types.Attribute.
+func Toggle(flag string, enabled bool) types.Attribute {
+	if err := helpers.ValidateAttributeName(flag); err != nil {
+		panic(err.Error())
+	}
+	return helpers.ToggleFlag(flag, enabled)
+}
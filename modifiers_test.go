@@ -0,0 +1,47 @@
+package godom_test
+
+import (
+	"bytes"
+
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/types"
+)
+
+func (suite *AttributesTestSuite) TestRemove() {
+	suite.testAttrAndFlag(HRef("/"), map[string]string{"href": "/"}, nil)
+	suite.testAttrAndFlag(Disabled(), map[string]string{"href": "/"}, []string{"disabled"})
+	suite.testAttrAndFlag(Remove("href"), map[string]string{}, []string{"disabled"})
+	suite.testAttrAndFlag(Remove("disabled"), map[string]string{}, []string{})
+	suite.testAttrAndFlag(Remove("missing"), map[string]string{}, []string{})
+}
+
+func (suite *AttributesTestSuite) TestOverride() {
+	suite.testAttr(HRef("/"), "href", "/")
+	suite.testAttr(Override("href", "/other"), "href", "/other")
+	suite.testAttrAndFlag(Remove("href"), map[string]string{}, nil)
+}
+
+func (suite *AttributesTestSuite) TestOverrideFlag() {
+	suite.testFlag(Hidden(), "hidden")
+	suite.testAttrAndFlag(Override("hidden", "until-found"), map[string]string{"hidden": "until-found"}, []string{})
+	// the panic value is the message, as for CustomAttr
+	suite.PanicsWithValue(`attribute name "a b" contains invalid character ' ' at position 1`, func() { Override("a b", "") })
+}
+
+func (suite *AttributesTestSuite) TestToggle() {
+	suite.testFlag(Toggle("disabled", true), "disabled")
+	suite.testFlag(Toggle("disabled", true), "disabled")
+	suite.testAttrAndFlag(Toggle("disabled", false), nil, []string{})
+	suite.PanicsWithValue(`attribute name "a=b" contains invalid character '=' at position 1`, func() { Toggle("a=b", true) })
+}
+
+func (suite *AttributesTestSuite) TestWrapperComponent() {
+	// a wrapper component that adjusts the attributes passed down by its caller
+	button := func(attrs ...types.Attribute) types.ElementFactory {
+		return Button(append(attrs, Override("type", "button"), Toggle("disabled", false), Remove("onclick"))...)
+	}
+
+	var buf bytes.Buffer
+	suite.NoError(button(Type("submit"), Disabled(), OnClick("submit()"), Class("btn"))(Content("OK")).Render(&buf))
+	suite.Equal(`<button class="btn" type="button">OK</button>`, buf.String())
+}
@@ -0,0 +1,231 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/tbe/godom/types"
+)
+
+// slotMarkerPrefix starts the marker of a slot that was rendered into an intermediate buffer of an element.
+// It is followed by the random token of the render, the decimal index of the slot and slotMarkerEnd.
+const (
+	slotMarkerPrefix = "\x00godom-slot-"
+	slotMarkerEnd    = '\x00'
+)
+
+// documentKey is the context key for the state of a Document render.
+type documentKey struct{}
+
+// document holds the state of a single Document render.
+type document struct {
+	mu        sync.Mutex
+	slots     []slot
+	values    map[any]any
+	resolving bool
+
+	// buf is the buffer the document is rendered into
+	buf *bytes.Buffer
+	// positions holds the offsets in buf of the slots that were rendered directly into buf
+	positions []position
+	// marker is the random prefix of the markers of slots that were rendered into intermediate buffers
+	marker []byte
+}
+
+// slot is a deferred part of the output, together with the context of its position in the element tree.
+type slot struct {
+	ctx  context.Context
+	fill func(w io.Writer) error
+}
+
+// position is the offset of a slot in the buffer of a document.
+type position struct {
+	offset int
+	slot   int
+}
+
+// Document renders the element in two phases. First, the element is rendered into a buffer, during which elements
+// can collect per-render state (see Local), and reserve positions in the output (see Slot). Afterward, the slots
+// are filled in the order of their appearance, and the output is written to w.
+//
+// This allows elements to emit content that depends on the whole document, like the collected assets of all
+// components emitted in the head of the document.
+func Document(w io.Writer, element types.Element) error {
+	doc := &document{values: make(map[any]any), buf: new(bytes.Buffer)}
+	ctx := context.WithValue(Context(w), documentKey{}, doc)
+
+	if err := element.Render(WithContext(doc.buf, ctx)); err != nil {
+		return err
+	}
+
+	doc.mu.Lock()
+	doc.resolving = true
+	doc.mu.Unlock()
+
+	data := doc.buf.Bytes()
+	positions := doc.positions
+	written := 0
+	for {
+		// the next slot is either recorded by its position, or marked in the output of an intermediate buffer
+		end, n := len(data), -1
+		if len(positions) > 0 {
+			end, n = positions[0].offset, positions[0].slot
+		}
+		next := end
+		if idx := markerIndex(data[written:end], doc.marker); idx >= 0 {
+			end = written + idx
+			rest := data[end+len(doc.marker):]
+			length := bytes.IndexByte(rest, slotMarkerEnd)
+			if length < 0 {
+				return fmt.Errorf("unterminated slot marker")
+			}
+			var err error
+			if n, err = strconv.Atoi(string(rest[:length])); err != nil {
+				return fmt.Errorf("invalid slot marker %q", rest[:length])
+			}
+			next = end + len(doc.marker) + length + 1
+		} else if len(positions) > 0 {
+			positions = positions[1:]
+		}
+
+		if _, err := w.Write(data[written:end]); err != nil {
+			return err
+		}
+		if n < 0 {
+			return nil
+		}
+		if n >= len(doc.slots) {
+			return fmt.Errorf("invalid slot marker %q", strconv.Itoa(n))
+		}
+		written = next
+
+		s := doc.slots[n]
+		if err := s.fill(WithContext(w, s.ctx)); err != nil {
+			return err
+		}
+	}
+}
+
+// markerIndex returns the index of the first slot marker in data, or -1 if there is none.
+func markerIndex(data, marker []byte) int {
+	if marker == nil {
+		return -1
+	}
+	return bytes.Index(data, marker)
+}
+
+// detachedKey is the context key that marks detached renders.
+type detachedKey struct{}
+
+// Detach returns a copy of ctx without the state of Document. Elements rendered with the returned context behave
+// as if they were not rendered by Document, e.g. Slot is filled immediately. This is required for output that is
+// reused across renders, like cached parts of a document. Elements that need the whole document can check Detached
+// to fail instead of being rendered at the wrong position.
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(context.WithValue(ctx, documentKey{}, nil), detachedKey{}, true)
+}
+
+// Detached reports whether w is rendered with a context returned by Detach, and not by a Document started within.
+func Detached(w io.Writer) bool {
+	detached, _ := Context(w).Value(detachedKey{}).(bool)
+	return detached && documentFrom(w) == nil
+}
+
+// streamingKey is the context key that marks streaming renders.
+type streamingKey struct{}
+
+// WithStreaming returns a copy of ctx that marks the render as streaming, i.e. the output is written while the
+// element tree is rendered, and not buffered by Document. Elements that need the whole document, like the entries
+// of the head package, can check Streaming to fail instead of being rendered at the wrong position.
+func WithStreaming(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamingKey{}, true)
+}
+
+// Streaming reports whether w is rendered by a streaming render (see WithStreaming) and not by Document.
+func Streaming(w io.Writer) bool {
+	streaming, _ := Context(w).Value(streamingKey{}).(bool)
+	return streaming && documentFrom(w) == nil
+}
+
+// documentFrom returns the Document state of w, or nil if w is not rendered by Document.
+func documentFrom(w io.Writer) *document {
+	doc, _ := Context(w).Value(documentKey{}).(*document)
+	return doc
+}
+
+// Local returns the per-render value stored under key. If there is no such value yet, it is created using init.
+// This allows elements to share state during a single render, e.g. to collect information from nested elements.
+//
+// Local returns nil if w is not rendered by Document.
+func Local(w io.Writer, key any, init func() any) any {
+	doc := documentFrom(w)
+	if doc == nil {
+		return nil
+	}
+
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+	value, exists := doc.values[key]
+	if !exists {
+		value = init()
+		doc.values[key] = value
+	}
+	return value
+}
+
+// Slot returns an element that reserves its position in the output. The fill function is called once the
+// whole element tree was rendered by Document, and writes the actual content of the slot.
+//
+// If the element is not rendered by Document, fill is called immediately.
+func Slot(fill func(w io.Writer) error) types.Element {
+	return &slotElement{fill: fill}
+}
+
+type slotElement struct {
+	fill func(w io.Writer) error
+}
+
+func (s *slotElement) Render(writer io.Writer) error {
+	doc := documentFrom(writer)
+	if doc == nil {
+		return s.fill(writer)
+	}
+
+	doc.mu.Lock()
+	if doc.resolving {
+		// slots rendered while filling other slots can not be deferred any further
+		doc.mu.Unlock()
+		return s.fill(writer)
+	}
+	n := len(doc.slots)
+	doc.slots = append(doc.slots, slot{ctx: Context(writer), fill: s.fill})
+
+	if cw, ok := writer.(*contextWriter); ok && cw.Writer == io.Writer(doc.buf) {
+		// the slot is rendered directly into the document, so we record its position out of band
+		doc.positions = append(doc.positions, position{offset: doc.buf.Len(), slot: n})
+		doc.mu.Unlock()
+		return nil
+	}
+
+	// the slot is rendered into an intermediate buffer, which is copied into the document later, so we have to mark
+	// its position in the output. The marker contains a random token, so it can not be forged by user content.
+	if doc.marker == nil {
+		var token [16]byte
+		if _, err := rand.Read(token[:]); err != nil {
+			doc.mu.Unlock()
+			return err
+		}
+		doc.marker = []byte(slotMarkerPrefix + hex.EncodeToString(token[:]) + "-")
+	}
+	marker := strconv.AppendInt(append([]byte(nil), doc.marker...), int64(n), 10)
+	doc.mu.Unlock()
+
+	_, err := writer.Write(append(marker, slotMarkerEnd))
+	return err
+}
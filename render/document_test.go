@@ -0,0 +1,151 @@
+package render_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+type counterKey struct{}
+
+// count increments the per-render counter and renders nothing.
+type count struct{}
+
+func (count) Render(w io.Writer) error {
+	if counter, ok := render.Local(w, counterKey{}, func() any { return new(int) }).(*int); ok {
+		*counter++
+	}
+	return nil
+}
+
+// total renders the final value of the per-render counter, or "-" outside of render.Document.
+func total() types.Element {
+	return render.Slot(func(w io.Writer) error {
+		counter, ok := render.Local(w, counterKey{}, func() any { return new(int) }).(*int)
+		if !ok {
+			_, err := io.WriteString(w, "-")
+			return err
+		}
+		_, err := io.WriteString(w, strconv.Itoa(*counter))
+		return err
+	})
+}
+
+func TestDocument(t *testing.T) {
+	doc := Div()(
+		Span()(total()),
+		count{},
+		P()(count{}, Content("text"), total()),
+		count{},
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, render.Document(&buf, doc))
+	assert.Equal(t, "<div><span>3</span><p>text3</p></div>", buf.String())
+
+	// the state is not shared between renders
+	buf.Reset()
+	assert.NoError(t, render.Document(&buf, doc))
+	assert.Equal(t, "<div><span>3</span><p>text3</p></div>", buf.String())
+}
+
+func TestDocumentContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	slot := render.Slot(func(w io.Writer) error {
+		_, err := io.WriteString(w, render.Context(w).Value(contextKey{}).(string))
+		return err
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, render.Document(render.WithContext(&buf, ctx), Div()(slot)))
+	assert.Equal(t, "<div>value</div>", buf.String())
+}
+
+func TestDocumentErrors(t *testing.T) {
+	fail := errors.New("fail")
+	slot := render.Slot(func(w io.Writer) error {
+		return fail
+	})
+
+	var buf bytes.Buffer
+	assert.ErrorIs(t, render.Document(&buf, Div()(slot)), fail)
+}
+
+func TestWithoutDocument(t *testing.T) {
+	var buf bytes.Buffer
+	doc := Div()(count{}, Content("-"), render.Slot(func(w io.Writer) error {
+		assert.Nil(t, render.Local(w, counterKey{}, func() any { return new(int) }))
+		_, err := io.WriteString(w, "slot")
+		return err
+	}))
+	assert.NoError(t, doc.Render(&buf))
+	assert.Equal(t, "<div>-slot</div>", buf.String())
+}
+
+func TestDetach(t *testing.T) {
+	detached := Div()(count{}, total())
+
+	var buf bytes.Buffer
+	assert.NoError(t, render.Document(&buf, Group(count{}, total(), Span()(detachElement{detached}))))
+	assert.Equal(t, "1<span><div>-</div></span>", buf.String())
+}
+
+func TestDetached(t *testing.T) {
+	var buf bytes.Buffer
+	assert.False(t, render.Detached(&buf))
+
+	detached := render.WithContext(&buf, render.Detach(context.Background()))
+	assert.True(t, render.Detached(detached))
+
+	// a document started within a detached render collects its own state
+	assert.NoError(t, render.Document(detached, render.Slot(func(w io.Writer) error {
+		assert.False(t, render.Detached(w))
+		return nil
+	})))
+}
+
+// detachElement renders the element detached from the document.
+type detachElement struct {
+	element types.Element
+}
+
+func (d detachElement) Render(w io.Writer) error {
+	return d.element.Render(render.WithContext(w, render.Detach(render.Context(w))))
+}
+
+// buffered renders the element into an intermediate buffer, and copies the buffer to the writer.
+type buffered struct {
+	element types.Element
+}
+
+func (b buffered) Render(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := b.element.Render(render.WithContext(&buf, render.Context(w))); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func TestDocumentForgedMarker(t *testing.T) {
+	forged := "\x00godom-slot-0\x00\x00godom-slot-7\x00"
+	doc := Div()(
+		total(),
+		P()(Content(forged)),
+		buffered{Span()(count{}, Content(forged), total())},
+		count{},
+		total(),
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, render.Document(&buf, doc))
+	assert.Equal(t, "<div>2<p>"+forged+"</p><span>"+forged+"2</span>2</div>", buf.String())
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"context"
+	"io"
+	"slices"
+)
+
+// AttributeHook is called for every element rendered by the helpers package, before its attributes are written.
+// It may modify the attributes and flags of the element, or fail the render by returning an error.
+// The attributes and flags are copies, so modifications only affect the current render.
+type AttributeHook func(tag string, attrs map[string]string, flags *[]string) error
+
+// hooksKey is the context key for the attribute hooks.
+type hooksKey struct{}
+
+// hookSet holds the attribute hooks of a render. It is never modified, as it may be shared with other renders.
+type hookSet struct {
+	hooks []registeredHook
+	// funcs holds all hooks, in the order they were added
+	funcs []AttributeHook
+	// tags holds the tags of all hooks that apply to specific tags, all is true if a hook applies to all tags
+	tags []string
+	all  bool
+}
+
+// registeredHook is an attribute hook together with the tags it applies to. A hook without tags applies to all tags.
+type registeredHook struct {
+	hook AttributeHook
+	tags []string
+}
+
+// applies reports whether the hook applies to elements with the tag.
+func (h registeredHook) applies(tag string) bool {
+	return len(h.tags) == 0 || slices.Contains(h.tags, tag)
+}
+
+// WithAttributeHook returns a copy of ctx that carries the hook in addition to the hooks already carried by ctx.
+// The hook is only called for elements with the given tags, or for all elements if no tags are given.
+// Hooks should declare their tags whenever possible, as the attributes of elements without hooks are only
+// formatted once, and their output can be precompiled and cached.
+// Use WithContext to render elements with the returned context.
+func WithAttributeHook(ctx context.Context, hook AttributeHook, tags ...string) context.Context {
+	parent, _ := ctx.Value(hooksKey{}).(*hookSet)
+	set := &hookSet{}
+	if parent != nil {
+		*set = *parent
+	}
+	// we never modify the parent slices, as they may be shared with other renders
+	set.hooks = append(set.hooks[:len(set.hooks):len(set.hooks)], registeredHook{hook: hook, tags: slices.Clone(tags)})
+	set.funcs = append(set.funcs[:len(set.funcs):len(set.funcs)], hook)
+	if len(tags) == 0 {
+		set.all = true
+	}
+	for _, tag := range tags {
+		if !slices.Contains(set.tags, tag) {
+			set.tags = append(set.tags[:len(set.tags):len(set.tags)], tag)
+		}
+	}
+	return context.WithValue(ctx, hooksKey{}, set)
+}
+
+// hooksFrom returns the attribute hooks of w, or nil if there are none.
+func hooksFrom(w io.Writer) *hookSet {
+	cw, ok := w.(*contextWriter)
+	if !ok {
+		return nil
+	}
+	set, _ := cw.ctx.Value(hooksKey{}).(*hookSet)
+	return set
+}
+
+// AttributeHooks returns the attribute hooks carried by w that apply to elements with the given tag, in the order
+// they were added.
+func AttributeHooks(w io.Writer, tag string) []AttributeHook {
+	set := hooksFrom(w)
+	if set == nil {
+		return nil
+	}
+
+	matches := 0
+	for _, h := range set.hooks {
+		if h.applies(tag) {
+			matches++
+		}
+	}
+	switch matches {
+	case 0:
+		return nil
+	case len(set.hooks):
+		return set.funcs
+	}
+	hooks := make([]AttributeHook, 0, matches)
+	for _, h := range set.hooks {
+		if h.applies(tag) {
+			hooks = append(hooks, h.hook)
+		}
+	}
+	return hooks
+}
+
+// AttributeHookTags returns the tags the attribute hooks carried by w apply to. If all is true, at least one hook
+// applies to all tags. Elements with other tags are rendered the same with and without the hooks.
+func AttributeHookTags(w io.Writer) (tags []string, all bool) {
+	set := hooksFrom(w)
+	if set == nil {
+		return nil, false
+	}
+	return set.tags, set.all
+}
+
+// WithoutAttributeHooks returns a copy of ctx without attribute hooks.
+func WithoutAttributeHooks(ctx context.Context) context.Context {
+	if _, exists := ctx.Value(hooksKey{}).(*hookSet); !exists {
+		return ctx
+	}
+	return context.WithValue(ctx, hooksKey{}, (*hookSet)(nil))
+}
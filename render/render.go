@@ -0,0 +1,63 @@
+// Package render provides the infrastructure to carry per-render state through the rendering of an element tree.
+//
+// As types.Element only receives an io.Writer, the state is attached to the writer itself. Elements that need
+// the state, like the streaming elements of the stream package, retrieve it from the writer they are rendered to.
+// Elements that render their children into intermediate buffers should use WithContext to keep the state available.
+package render
+
+import (
+	"context"
+	"io"
+)
+
+// contextWriter is an io.Writer that carries a context.Context.
+type contextWriter struct {
+	io.Writer
+	ctx context.Context
+}
+
+// WithContext returns an io.Writer that writes to w and carries ctx.
+// The context can be retrieved by the elements rendered to the returned writer using Context.
+func WithContext(w io.Writer, ctx context.Context) io.Writer {
+	// we never nest our writers, the context already contains all parent values
+	if cw, ok := w.(*contextWriter); ok {
+		w = cw.Writer
+	}
+	return &contextWriter{Writer: w, ctx: ctx}
+}
+
+// Context returns the context.Context carried by w.
+// If w does not carry a context, context.Background is returned.
+func Context(w io.Writer) context.Context {
+	if cw, ok := w.(*contextWriter); ok {
+		return cw.ctx
+	}
+	return context.Background()
+}
+
+// WriteString writes s to the underlying writer, avoiding a copy if the underlying writer supports it.
+func (w *contextWriter) WriteString(s string) (int, error) {
+	return io.WriteString(w.Writer, s)
+}
+
+// Flush flushes the underlying writer.
+func (w *contextWriter) Flush() error {
+	return Flush(w.Writer)
+}
+
+// Unwrap returns the underlying writer.
+func (w *contextWriter) Unwrap() io.Writer {
+	return w.Writer
+}
+
+// Flush flushes w, if it supports flushing. It supports both http.Flusher and writers with a
+// `Flush() error` method, like bufio.Writer.
+func Flush(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package render_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom/render"
+)
+
+type contextKey struct{}
+
+func TestContext(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Equal(t, context.Background(), render.Context(&buf))
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	w := render.WithContext(&buf, ctx)
+	assert.Equal(t, "value", render.Context(w).Value(contextKey{}))
+
+	// writers are not nested, but the new context replaces the old one
+	nested := render.WithContext(w, context.WithValue(render.Context(w), contextKey{}, "nested"))
+	assert.Equal(t, "nested", render.Context(nested).Value(contextKey{}))
+	assert.Same(t, &buf, nested.(interface{ Unwrap() io.Writer }).Unwrap())
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := render.WithContext(&buf, context.Background())
+
+	_, err := w.Write([]byte("<p>"))
+	assert.NoError(t, err)
+	_, err = w.(interface{ WriteString(string) (int, error) }).WriteString("</p>")
+	assert.NoError(t, err)
+	assert.Equal(t, "<p></p>", buf.String())
+}
+
+func TestFlush(t *testing.T) {
+	// http.Flusher
+	{
+		rec := httptest.NewRecorder()
+		assert.NoError(t, render.Flush(render.WithContext(rec, context.Background())))
+		assert.True(t, rec.Flushed)
+	}
+
+	// bufio.Writer
+	{
+		var buf bytes.Buffer
+		bw := bufio.NewWriter(&buf)
+		_, _ = bw.WriteString("test")
+		assert.Empty(t, buf.String())
+
+		assert.NoError(t, render.Flush(render.WithContext(bw, context.Background())))
+		assert.Equal(t, "test", buf.String())
+	}
+
+	// writers without flush support are ignored
+	{
+		var buf bytes.Buffer
+		assert.NoError(t, render.Flush(&buf))
+	}
+}
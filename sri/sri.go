@@ -0,0 +1,196 @@
+/*
+Package sri computes Subresource Integrity (https://www.w3.org/TR/SRI/) digests for scripts and stylesheets.
+
+The digests are computed from the files of an fs.FS, usually an embed.FS, and cached, so creating an element
+does not rehash the file:
+
+	//go:embed static
+	var static embed.FS
+
+	Script(sri.ScriptWithSRI(static, "static/app.js"))()
+	// <script crossorigin="anonymous" integrity="sha384-..." src="static/app.js"></script>
+
+If the files are served below another URL, use a Hasher with a URL prefix. A Hasher can also compute all digests
+at startup with Precompute, so missing files are detected early.
+*/
+package sri
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io/fs"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/types"
+)
+
+// Algorithm is a hash algorithm supported by Subresource Integrity.
+type Algorithm string
+
+// The hash algorithms supported by Subresource Integrity.
+const (
+	SHA256 Algorithm = "sha256"
+	SHA384 Algorithm = "sha384"
+	SHA512 Algorithm = "sha512"
+)
+
+// DefaultAlgorithm is the algorithm used by ScriptWithSRI and LinkWithSRI.
+const DefaultAlgorithm = SHA384
+
+// CrossOriginAnonymous is the CrossOrigin value emitted together with the integrity, as required for
+// cross-origin resources.
+const CrossOriginAnonymous = "anonymous"
+
+func (a Algorithm) new() (hash.Hash, error) {
+	switch a {
+	case SHA256:
+		return sha256.New(), nil
+	case SHA384:
+		return sha512.New384(), nil
+	case SHA512:
+		return sha512.New(), nil
+	}
+	return nil, fmt.Errorf("sri: unsupported algorithm %q", string(a))
+}
+
+// Digest returns the integrity value of the data, e.g. "sha384-...". It panics if the algorithm is not supported.
+func Digest(algorithm Algorithm, data []byte) string {
+	h, err := algorithm.new()
+	if err != nil {
+		panic(err)
+	}
+	h.Write(data)
+	return string(algorithm) + "-" + base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Hasher computes and caches the integrity values of the files of an fs.FS. It is safe for concurrent use.
+type Hasher struct {
+	fsys      fs.FS
+	algorithm Algorithm
+	prefix    string
+
+	mu      sync.RWMutex
+	digests map[string]string
+}
+
+// NewHasher returns a Hasher for the files of fsys.
+func NewHasher(fsys fs.FS, algorithm Algorithm) *Hasher {
+	return &Hasher{fsys: fsys, algorithm: algorithm, digests: make(map[string]string)}
+}
+
+// WithPrefix sets the URL prefix of the files. The URL of a file is the prefix followed by its name.
+func (h *Hasher) WithPrefix(prefix string) *Hasher {
+	h.prefix = prefix
+	return h
+}
+
+// Integrity returns the integrity value of the named file.
+func (h *Hasher) Integrity(name string) (string, error) {
+	h.mu.RLock()
+	digest, exists := h.digests[name]
+	h.mu.RUnlock()
+	if exists {
+		return digest, nil
+	}
+
+	if _, err := h.algorithm.new(); err != nil {
+		return "", err
+	}
+	data, err := fs.ReadFile(h.fsys, name)
+	if err != nil {
+		return "", err
+	}
+	digest = Digest(h.algorithm, data)
+
+	h.mu.Lock()
+	h.digests[name] = digest
+	h.mu.Unlock()
+	return digest, nil
+}
+
+// Precompute computes the integrity values of all files with one of the given extensions, e.g. ".js" and ".css".
+// Without extensions, all files are hashed.
+func (h *Hasher) Precompute(extensions ...string) error {
+	return fs.WalkDir(h.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !hasExtension(name, extensions) {
+			return err
+		}
+		_, err = h.Integrity(name)
+		return err
+	})
+}
+
+func hasExtension(name string, extensions []string) bool {
+	if len(extensions) == 0 {
+		return true
+	}
+	for _, ext := range extensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Script returns the Src, Integrity and CrossOrigin attributes for the named script.
+// It panics if the integrity value can not be computed.
+func (h *Hasher) Script(name string) types.Attribute {
+	return h.attributes(godom.Src, name)
+}
+
+// Link returns the HRef, Integrity and CrossOrigin attributes for the named stylesheet or preloaded resource.
+// It panics if the integrity value can not be computed.
+func (h *Hasher) Link(name string) types.Attribute {
+	return h.attributes(godom.HRef, name)
+}
+
+func (h *Hasher) attributes(url func(string) types.Attribute, name string) types.Attribute {
+	digest, err := h.Integrity(name)
+	if err != nil {
+		panic(fmt.Sprintf("failed to compute integrity of %s: %v", name, err))
+	}
+
+	attrs := []types.Attribute{url(h.prefix + name), godom.Integrity(digest), godom.CrossOrigin(CrossOriginAnonymous)}
+	return func(attributes map[string]string, flags *[]string, delayed *[]types.Attribute) {
+		for _, attr := range attrs {
+			attr(attributes, flags, delayed)
+		}
+	}
+}
+
+// hashers caches the Hasher of every embed.FS and pointer file system used with ScriptWithSRI and LinkWithSRI.
+var hashers sync.Map
+
+// hasherFor returns the cached Hasher of fsys. Only an embed.FS and file systems that are pointers are cached,
+// as other values may not be hashable, like a struct holding an fstest.MapFS, or may be created for every call.
+// For all other file systems, a new Hasher is returned.
+func hasherFor(fsys fs.FS) *Hasher {
+	if _, embedded := fsys.(embed.FS); !embedded && (fsys == nil || reflect.TypeOf(fsys).Kind() != reflect.Pointer) {
+		return NewHasher(fsys, DefaultAlgorithm)
+	}
+	h, _ := hashers.LoadOrStore(fsys, NewHasher(fsys, DefaultAlgorithm))
+	return h.(*Hasher)
+}
+
+// ScriptWithSRI returns the Src, Integrity and CrossOrigin attributes for the named script of fsys.
+// It panics if the integrity value can not be computed.
+//
+// The integrity value is computed once per file and file system, if fsys is an embed.FS or a pointer. These file
+// systems are kept for the lifetime of the program. For other file systems, the file is hashed by every call, so
+// use a Hasher instead.
+func ScriptWithSRI(fsys fs.FS, name string) types.Attribute {
+	return hasherFor(fsys).Script(name)
+}
+
+// LinkWithSRI returns the HRef, Integrity and CrossOrigin attributes for the named stylesheet of fsys.
+// It panics if the integrity value can not be computed. Integrity values are cached like by ScriptWithSRI.
+func LinkWithSRI(fsys fs.FS, name string) types.Attribute {
+	return hasherFor(fsys).Link(name)
+}
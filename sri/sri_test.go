@@ -0,0 +1,91 @@
+package sri_test
+
+import (
+	"bytes"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/sri"
+)
+
+func TestSRI(t *testing.T) {
+	suite.Run(t, new(SRITestSuite))
+}
+
+type SRITestSuite struct {
+	suite.Suite
+}
+
+// countingFS counts the read files. As a pointer, it is comparable and can be cached.
+type countingFS struct {
+	fstest.MapFS
+	opened int
+}
+
+func (c *countingFS) ReadFile(name string) ([]byte, error) {
+	c.opened++
+	return c.MapFS.ReadFile(name)
+}
+
+func newFS() *countingFS {
+	return &countingFS{MapFS: fstest.MapFS{
+		"app.js":    {Data: []byte("alert('Hello world.');")},
+		"main.css":  {Data: []byte("body { color: red; }")},
+		"README.md": {Data: []byte("# Static files")},
+	}}
+}
+
+func (suite *SRITestSuite) TestDigest() {
+	// test vectors computed with `openssl dgst -sha384 -binary | openssl base64 -A`
+	data := []byte("alert('Hello world.');")
+	suite.Equal("sha256-pkWDkvDS/E+dKqzLkzXP8oPhxswpUr6T2+yIelFTPX4=", sri.Digest(sri.SHA256, data))
+	suite.Equal("sha384-aA8frZhXPBNWPumWay93MM5GbFCOqHPIGoTJ7ki59l8AhN+quBpVXXdSYf2AhtDE", sri.Digest(sri.SHA384, data))
+	suite.Panics(func() { sri.Digest("md5", data) })
+}
+
+func (suite *SRITestSuite) TestScriptWithSRI() {
+	fsys := newFS()
+	var buf bytes.Buffer
+	suite.NoError(Script(sri.ScriptWithSRI(fsys, "app.js"))().Render(&buf))
+	suite.Equal(
+		`<script crossorigin="anonymous" integrity="sha384-aA8frZhXPBNWPumWay93MM5GbFCOqHPIGoTJ7ki59l8AhN+quBpVXXdSYf2AhtDE" src="app.js"></script>`,
+		buf.String(),
+	)
+
+	// the digest is cached
+	sri.ScriptWithSRI(fsys, "app.js")
+	suite.Equal(1, fsys.opened)
+
+	suite.Panics(func() { sri.ScriptWithSRI(fsys, "missing.js") })
+}
+
+func (suite *SRITestSuite) TestUncachedFS() {
+	// a comparable struct holding a map can not be used as a key of the cache
+	wrapped := struct{ fs.FS }{newFS().MapFS}
+	var buf bytes.Buffer
+	suite.NoError(Link(sri.LinkWithSRI(wrapped, "main.css")).Render(&buf))
+	suite.Contains(buf.String(), `integrity="sha384-`)
+
+	suite.NoError(Link(sri.LinkWithSRI(newFS().MapFS, "main.css")).Render(&buf))
+}
+
+func (suite *SRITestSuite) TestHasher() {
+	fsys := newFS()
+	hasher := sri.NewHasher(fsys, sri.SHA256).WithPrefix("/static/")
+	suite.NoError(hasher.Precompute(".js", ".css"))
+	suite.Equal(2, fsys.opened)
+
+	var buf bytes.Buffer
+	suite.NoError(Link(Rel("stylesheet"), hasher.Link("main.css")).Render(&buf))
+	suite.Equal(2, fsys.opened)
+	suite.Contains(buf.String(), `href="/static/main.css"`)
+	suite.Contains(buf.String(), `integrity="sha256-`)
+
+	_, err := sri.NewHasher(fsys, "md5").Integrity("app.js")
+	suite.Error(err)
+	_, err = hasher.Integrity("missing.js")
+	suite.Error(err)
+}
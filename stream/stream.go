@@ -0,0 +1,233 @@
+/*
+Package stream provides streaming rendering of godom documents.
+
+Large documents are usually rendered completely before the client receives anything. Using Render, the output is
+flushed at marked boundaries instead: at every Flush element, after every Head created by this package, and at the
+end of the document.
+
+Additionally, parts of the document can be suspended. A Suspense element renders a fallback first, while its actual
+content is created concurrently. Once the document is complete, the suspended contents are streamed in the order
+they finish, each as a Template element together with an inline script that swaps it into the placeholder.
+
+Rendered outside of Render, all elements of this package degrade gracefully: Flush flushes the writer if possible,
+and Suspense renders its content in place.
+
+As the output is written while the document is rendered, Render does not use render.Document, and elements that
+collect per-render state can not be used. In particular, the entries and assets of the head package fail the
+render with head.ErrStreaming, as they would otherwise be written at their position in the body. Use the plain
+elements, like godom.Title, in the Head instead.
+*/
+package stream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+// IDPrefix is the prefix of the element IDs used for suspended content.
+const IDPrefix = "godom-suspense-"
+
+// stateKey is the context key for the streaming state.
+type stateKey struct{}
+
+// parentKey is the context key for the ID of the Suspense element whose content is currently rendered.
+type parentKey struct{}
+
+// state holds the suspended contents of a single Render call.
+type state struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	next    int
+	pending int
+	results chan result
+}
+
+// result is the rendered content of a Suspense element.
+type result struct {
+	id       int
+	parent   int
+	content  []byte
+	err      error
+	panicked bool
+	panic    any
+}
+
+// Render renders the element to w, flushing the output at the boundaries marked by the elements of this package.
+// Once the element is rendered, the contents of all Suspense elements are streamed as soon as they are available.
+// Rendering is aborted if the context of w (see render.Context) is canceled.
+// If the creator of a Suspense element panics, the panic is raised again by Render.
+func Render(w io.Writer, element types.Element) error {
+	ctx, cancel := context.WithCancel(render.Context(w))
+	defer cancel()
+
+	st := &state{results: make(chan result)}
+	st.ctx = context.WithValue(render.WithStreaming(ctx), stateKey{}, st)
+	w = render.WithContext(w, st.ctx)
+
+	if err := element.Render(w); err != nil {
+		return err
+	}
+	if err := render.Flush(w); err != nil {
+		return err
+	}
+
+	// suspended contents are written once the content of their parent was written, so their placeholder exists
+	written := map[int]bool{0: true}
+	waiting := make(map[int][]result)
+	for st.remaining() > 0 {
+		var res result
+		select {
+		case res = <-st.results:
+			st.received()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if res.panicked {
+			panic(res.panic)
+		}
+		if res.err != nil {
+			return res.err
+		}
+
+		ready := []result{res}
+		for len(ready) > 0 {
+			res, ready = ready[0], ready[1:]
+			if !written[res.parent] {
+				waiting[res.parent] = append(waiting[res.parent], res)
+				continue
+			}
+			if err := swap(res).Render(w); err != nil {
+				return err
+			}
+			written[res.id] = true
+			ready = append(ready, waiting[res.id]...)
+			delete(waiting, res.id)
+		}
+		if err := render.Flush(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stateFrom returns the streaming state of w, or nil if w is not rendered by Render.
+func stateFrom(w io.Writer) *state {
+	st, _ := render.Context(w).Value(stateKey{}).(*state)
+	return st
+}
+
+// remaining returns the number of suspended contents that are not yet received.
+// Nested Suspense elements are registered before the content of their parent is sent, so the result is never too low.
+func (st *state) remaining() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.pending
+}
+
+// received marks a suspended content as received.
+func (st *state) received() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.pending--
+}
+
+// suspend registers a new suspended content and starts creating it in the background.
+// It returns the ID of the new Suspense element.
+func (st *state) suspend(parent int, creator util.ElementCreator) int {
+	st.mu.Lock()
+	st.next++
+	st.pending++
+	id := st.next
+	st.mu.Unlock()
+
+	go func() {
+		res := result{id: id, parent: parent}
+		defer func() {
+			// a panic is raised again by the rendering goroutine, as a panic of this goroutine would not be
+			// recovered by the caller, e.g. by net/http, and would crash the program
+			if v := recover(); v != nil {
+				res.panicked, res.panic = true, v
+			}
+			select {
+			case st.results <- res:
+			case <-st.ctx.Done():
+			}
+		}()
+
+		var buf bytes.Buffer
+		res.err = creator().Render(render.WithContext(&buf, context.WithValue(st.ctx, parentKey{}, id)))
+		res.content = buf.Bytes()
+	}()
+	return id
+}
+
+// Flush returns an element that flushes the output when it is rendered.
+func Flush() types.Element {
+	return flushElement{}
+}
+
+type flushElement struct{}
+
+func (flushElement) Render(writer io.Writer) error {
+	return render.Flush(writer)
+}
+
+// Head works like godom.Head, but flushes the output after the head was rendered.
+// This allows the client to start loading stylesheets and scripts while the body is still rendered.
+func Head(attrs ...types.Attribute) types.ElementFactory {
+	head := godom.Head(attrs...)
+	return func(children ...types.Element) types.Element {
+		return godom.Group(head(children...), Flush())
+	}
+}
+
+// Suspense returns an element that renders the fallback in place, and streams the element returned by the creator
+// once it is available. The creator is invoked concurrently to the rendering of the rest of the document.
+//
+// The fallback is wrapped in a Div element, which is replaced by the content once it is streamed.
+func Suspense(fallback types.Element, creator util.ElementCreator) types.Element {
+	return &suspenseElement{fallback: fallback, creator: creator}
+}
+
+type suspenseElement struct {
+	fallback types.Element
+	creator  util.ElementCreator
+}
+
+func (s *suspenseElement) Render(writer io.Writer) error {
+	st := stateFrom(writer)
+	if st == nil {
+		return s.creator().Render(writer)
+	}
+
+	parent, _ := render.Context(writer).Value(parentKey{}).(int)
+	id := st.suspend(parent, s.creator)
+	return godom.Div(godom.ID(placeholderID(id)))(s.fallback).Render(writer)
+}
+
+// swap returns the elements that transport the suspended content, and swap it into its placeholder.
+func swap(res result) types.Element {
+	placeholder := placeholderID(res.id)
+	content := placeholder + "-content"
+	return godom.Group(
+		godom.Template(godom.ID(content))(helpers.NewStringElement(string(res.content))),
+		godom.Script()(helpers.NewStringElement(fmt.Sprintf(
+			`(function(){var p=document.getElementById(%q),t=document.getElementById(%q);p.replaceWith(t.content);t.remove();})();`,
+			placeholder, content,
+		))),
+	)
+}
+
+// placeholderID returns the element ID of the placeholder of the Suspense element with the given ID.
+func placeholderID(id int) string {
+	return fmt.Sprintf("%s%d", IDPrefix, id)
+}
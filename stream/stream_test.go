@@ -0,0 +1,154 @@
+package stream_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/head"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/stream"
+	"github.com/tbe/godom/types"
+)
+
+type StreamTestSuite struct {
+	suite.Suite
+
+	w flushRecorder
+}
+
+// TestStreamTestSuite initializes the test suite.
+func TestStreamTestSuite(t *testing.T) {
+	suite.Run(t, new(StreamTestSuite))
+}
+
+func (s *StreamTestSuite) SetupTest() {
+	s.w = flushRecorder{}
+}
+
+// flushRecorder records the output that was written up to every flush.
+type flushRecorder struct {
+	bytes.Buffer
+	flushes []string
+	onFlush func()
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushes = append(f.flushes, f.String())
+	if f.onFlush != nil {
+		f.onFlush()
+	}
+}
+
+// errorElement is an element that fails to render.
+type errorElement struct{}
+
+func (errorElement) Render(_ io.Writer) error {
+	return errors.New("render failed")
+}
+
+func (s *StreamTestSuite) TestFlush() {
+	doc := HTML()(
+		stream.Head()(Title()(Content("Title"))),
+		Body()(P()(Content("first")), stream.Flush(), P()(Content("second"))),
+	)
+	s.NoError(stream.Render(&s.w, doc))
+
+	s.Equal([]string{
+		"<html><head><title>Title</title></head>",
+		"<html><head><title>Title</title></head><body><p>first</p>",
+		"<html><head><title>Title</title></head><body><p>first</p><p>second</p></body></html>",
+	}, s.w.flushes)
+}
+
+func (s *StreamTestSuite) TestSuspense() {
+	release := make(chan struct{})
+	doc := Body()(
+		stream.Suspense(Content("loading"), func() types.Element {
+			<-release
+			return P()(Content("loaded"))
+		}),
+		stream.Flush(),
+	)
+
+	// the suspended content is only created once the document is already flushed
+	s.w.onFlush = func() {
+		if len(s.w.flushes) == 1 {
+			close(release)
+		}
+	}
+	s.NoError(stream.Render(&s.w, doc))
+
+	flushes := s.w.flushes
+	s.Equal(`<body><div id="godom-suspense-1">loading</div>`, flushes[0])
+	s.Contains(s.w.String(), `<template id="godom-suspense-1-content"><p>loaded</p></template><script>`)
+	s.Contains(s.w.String(), `getElementById("godom-suspense-1")`)
+}
+
+func (s *StreamTestSuite) TestNestedSuspense() {
+	doc := stream.Suspense(Content("outer loading"), func() types.Element {
+		return Div()(stream.Suspense(Content("inner loading"), func() types.Element {
+			return Content("inner")
+		}))
+	})
+	s.NoError(stream.Render(&s.w, doc))
+
+	out := s.w.String()
+	outer := bytes.Index([]byte(out), []byte(`<template id="godom-suspense-1-content">`))
+	inner := bytes.Index([]byte(out), []byte(`<template id="godom-suspense-2-content">inner</template>`))
+	s.GreaterOrEqual(outer, 0)
+	s.Greater(inner, outer, "the inner content must be streamed after its placeholder")
+	s.Contains(out, `<div><div id="godom-suspense-2">inner loading</div></div>`)
+}
+
+func (s *StreamTestSuite) TestSuspenseWithoutStreaming() {
+	doc := Div()(stream.Suspense(Content("loading"), func() types.Element {
+		return Content("loaded")
+	}), stream.Flush())
+	s.NoError(doc.Render(&s.w))
+
+	s.Equal("<div>loaded</div>", s.w.String())
+	s.Equal([]string{"<div>loaded"}, s.w.flushes)
+}
+
+func (s *StreamTestSuite) TestSuspenseError() {
+	doc := stream.Suspense(Content("loading"), func() types.Element {
+		return errorElement{}
+	})
+	s.Error(stream.Render(&s.w, doc))
+}
+
+func (s *StreamTestSuite) TestSuspensePanic() {
+	doc := stream.Suspense(Content("loading"), func() types.Element {
+		panic("broken")
+	})
+	// the panic is raised by the rendering goroutine, so it can be recovered by the caller
+	s.PanicsWithValue("broken", func() { _ = stream.Render(&s.w, doc) })
+}
+
+func (s *StreamTestSuite) TestCanceledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	doc := stream.Suspense(Content("loading"), func() types.Element {
+		cancel()
+		<-release
+		return Content("loaded")
+	})
+	err := stream.Render(render.WithContext(&s.w, ctx), doc)
+	s.ErrorIs(err, context.Canceled)
+}
+
+func (s *StreamTestSuite) TestHeadEntries() {
+	doc := HTML()(
+		stream.Head()(Title()(Content("Title"))),
+		Body()(head.Title("Article")),
+	)
+	s.ErrorIs(stream.Render(&s.w, doc), head.ErrStreaming)
+	s.NotContains(s.w.String(), "Article")
+}
@@ -0,0 +1,96 @@
+package structured
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// The ItemScope flag creates a new microdata item.
+//
+// This is a global types.Attribute.
+func ItemScope() types.Attribute {
+	return helpers.FlagAttribute("itemscope")
+}
+
+// The ItemType attribute specifies the vocabulary URLs of a microdata item, e.g. "https://schema.org/Article".
+//
+// This is a global types.Attribute.
+func ItemType(urls ...string) types.Attribute {
+	return helpers.MultiValueAttribute("itemtype", urls...)
+}
+
+// The ItemProp attribute adds the content of the element as properties to a microdata item.
+//
+// This is a global types.Attribute.
+func ItemProp(names ...string) types.Attribute {
+	return helpers.MultiValueAttribute("itemprop", names...)
+}
+
+// The ItemID attribute specifies the global identifier of a microdata item.
+//
+// This is a global types.Attribute.
+func ItemID(url string) types.Attribute {
+	return helpers.SingleAttribute("itemid", url)
+}
+
+// The ItemRef attribute specifies the IDs of elements with additional properties of a microdata item.
+//
+// This is a global types.Attribute.
+func ItemRef(ids ...string) types.Attribute {
+	return helpers.MultiValueAttribute("itemref", ids...)
+}
+
+// The Vocab attribute specifies the default RDFa vocabulary, e.g. "https://schema.org/".
+//
+// This is a global types.Attribute.
+func Vocab(url string) types.Attribute {
+	return helpers.SingleAttribute("vocab", url)
+}
+
+// The TypeOf attribute specifies the RDFa types of a resource.
+//
+// This is a global types.Attribute.
+func TypeOf(typeNames ...string) types.Attribute {
+	return helpers.MultiValueAttribute("typeof", typeNames...)
+}
+
+// The Property attribute specifies the RDFa properties that the content of the element describes.
+//
+// This is a global types.Attribute.
+func Property(properties ...string) types.Attribute {
+	return helpers.MultiValueAttribute("property", properties...)
+}
+
+// The Resource attribute specifies the subject of RDFa properties.
+//
+// This is a global types.Attribute.
+func Resource(url string) types.Attribute {
+	return helpers.SingleAttribute("resource", url)
+}
+
+// The About attribute specifies the subject of RDFa properties.
+//
+// This is a global types.Attribute.
+func About(url string) types.Attribute {
+	return helpers.SingleAttribute("about", url)
+}
+
+// The Prefix attribute maps RDFa prefixes to vocabulary URLs.
+//
+// This is a global types.Attribute.
+func Prefix(mappings map[string]string) types.Attribute {
+	prefixes := make([]string, 0, len(mappings))
+	for prefix := range mappings {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+
+	parts := make([]string, 0, 2*len(prefixes))
+	for _, prefix := range prefixes {
+		parts = append(parts, prefix+":", mappings[prefix])
+	}
+	return helpers.SingleAttribute("prefix", strings.Join(parts, " "))
+}
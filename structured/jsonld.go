@@ -0,0 +1,59 @@
+/*
+Package structured provides helpers for structured data, as used by search engines: JSON-LD scripts, as well as
+microdata and RDFa attributes for the element constructors of godom.
+
+JSON-LD data is provided as Go value, and rendered into a Script element:
+
+	structured.JSONLD(map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "Article",
+		"headline": "Blog Post Title",
+	})
+
+Microdata and RDFa annotate the existing elements instead:
+
+	Article(structured.ItemScope(), structured.ItemType("https://schema.org/Article"))(
+		H1(structured.ItemProp("headline"))(Content("Blog Post Title")),
+	)
+*/
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/helpers"
+	"github.com/tbe/godom/types"
+)
+
+// JSONLDType is the type of JSON-LD script elements.
+const JSONLDType = "application/ld+json"
+
+// JSONLD returns a Script element that contains the JSON-LD representation of data.
+// The data is encoded with encoding/json. If the encoding fails, rendering the element returns the error.
+//
+// The characters <, > and & are escaped as JSON unicode escapes, so the data can never close the script element,
+// regardless of its contents.
+func JSONLD(data any) types.Element {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(true)
+	if err := enc.Encode(data); err != nil {
+		return &errorElement{err: err}
+	}
+	// the encoder terminates every value with a newline
+	content := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+
+	return godom.Script(godom.Type(JSONLDType))(helpers.NewStringElement(string(content)))
+}
+
+// errorElement is an element that always fails to render with the given error.
+type errorElement struct {
+	err error
+}
+
+func (e *errorElement) Render(_ io.Writer) error {
+	return e.err
+}
@@ -0,0 +1,95 @@
+package structured_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/structured"
+	"github.com/tbe/godom/types"
+)
+
+func TestStructured(t *testing.T) {
+	suite.Run(t, new(StructuredTestSuite))
+}
+
+type StructuredTestSuite struct {
+	suite.Suite
+	buf bytes.Buffer
+}
+
+func (suite *StructuredTestSuite) SetupTest() {
+	suite.buf.Reset()
+}
+
+func (suite *StructuredTestSuite) testAttr(attribute types.Attribute, key, value string) {
+	attrs := make(map[string]string)
+	var flags []string
+	attribute(attrs, &flags, nil)
+
+	suite.Equal(map[string]string{key: value}, attrs)
+	suite.Empty(flags)
+}
+
+type article struct {
+	Context  string `json:"@context"`
+	Type     string `json:"@type"`
+	Headline string `json:"headline"`
+}
+
+func (suite *StructuredTestSuite) TestJSONLD() {
+	el := structured.JSONLD(article{Context: "https://schema.org", Type: "Article", Headline: "Title"})
+	suite.NoError(el.Render(&suite.buf))
+	suite.Equal(
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","headline":"Title"}</script>`,
+		suite.buf.String(),
+	)
+}
+
+func (suite *StructuredTestSuite) TestJSONLDEscaping() {
+	el := structured.JSONLD(map[string]string{"headline": "</script><script>alert(1)</script> & <!--"})
+	suite.NoError(el.Render(&suite.buf))
+	suite.Equal(
+		`<script type="application/ld+json">{"headline":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e \u0026 \u003c!--"}</script>`,
+		suite.buf.String(),
+	)
+}
+
+func (suite *StructuredTestSuite) TestJSONLDError() {
+	suite.Error(structured.JSONLD(map[string]any{"invalid": make(chan int)}).Render(&suite.buf))
+}
+
+func (suite *StructuredTestSuite) TestMicrodata() {
+	var flags []string
+	structured.ItemScope()(nil, &flags, nil)
+	suite.Equal([]string{"itemscope"}, flags)
+
+	suite.testAttr(structured.ItemType("https://schema.org/Article"), "itemtype", "https://schema.org/Article")
+	suite.testAttr(structured.ItemProp("name", "headline"), "itemprop", "name headline")
+	suite.testAttr(structured.ItemID("urn:isbn:0-330-34122-8"), "itemid", "urn:isbn:0-330-34122-8")
+	suite.testAttr(structured.ItemRef("a", "b"), "itemref", "a b")
+}
+
+func (suite *StructuredTestSuite) TestRDFa() {
+	suite.testAttr(structured.Vocab("https://schema.org/"), "vocab", "https://schema.org/")
+	suite.testAttr(structured.TypeOf("Person"), "typeof", "Person")
+	suite.testAttr(structured.Property("name"), "property", "name")
+	suite.testAttr(structured.Resource("#me"), "resource", "#me")
+	suite.testAttr(structured.About("#me"), "about", "#me")
+	suite.testAttr(
+		structured.Prefix(map[string]string{"og": "https://ogp.me/ns#", "dc": "http://purl.org/dc/terms/"}),
+		"prefix", "dc: http://purl.org/dc/terms/ og: https://ogp.me/ns#",
+	)
+}
+
+func (suite *StructuredTestSuite) TestMicrodataDocument() {
+	doc := Div(structured.ItemScope(), structured.ItemType("https://schema.org/Person"))(
+		Span(structured.ItemProp("name"))(Content("Jane Doe")),
+	)
+	suite.NoError(doc.Render(&suite.buf))
+	suite.Equal(
+		`<div itemscope itemtype="https://schema.org/Person"><span itemprop="name">Jane Doe</span></div>`,
+		suite.buf.String(),
+	)
+}
@@ -0,0 +1,164 @@
+package template
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/tbe/godom/types"
+	"golang.org/x/exp/maps"
+)
+
+// Builder constructs the body of a template.
+// It receives the template it builds, so it can define placeholders, attributes and fallbacks, and returns the root
+// element that is parsed into the template.
+type Builder func(t *Template) types.Element
+
+// Set is a registry of named templates.
+// Handlers can execute templates by name instead of holding references to the individual templates.
+//
+// In development mode, templates are rebuilt from their Builder on every lookup, and registering a template
+// with an already used name replaces the existing template. This allows to pick up changes without restarting.
+type Set struct {
+	mu          sync.RWMutex
+	templates   map[string]*setEntry
+	development bool
+	compiled    bool
+}
+
+// setEntry holds a registered template together with the builder used to create it.
+type setEntry struct {
+	builder Builder
+	tmpl    *Template
+	// compiled reports whether the template is compiled, as specified by Set.Compiled when it was registered
+	compiled bool
+}
+
+// NewSet initializes and returns a new, empty template Set.
+func NewSet() *Set {
+	return &Set{templates: make(map[string]*setEntry)}
+}
+
+// Development enables or disables the development mode of the set.
+func (s *Set) Development(enabled bool) *Set {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.development = enabled
+	return s
+}
+
+// Compiled specifies if templates registered afterward are compiled (see Template.Compile) instead of parsed.
+// Templates that are already registered keep their mode, also when they are rebuilt.
+func (s *Set) Compiled(enabled bool) *Set {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compiled = enabled
+	return s
+}
+
+// Register builds a new template with the given name and adds it to the set.
+// It returns an error if a template with the same name already exists, unless the set is in development mode.
+func (s *Set) Register(name string, builder Builder) (*Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.templates[name]; exists && !s.development {
+		return nil, fmt.Errorf("template %q already exists", name)
+	}
+
+	entry := &setEntry{builder: builder, compiled: s.compiled}
+	tmpl, err := entry.build(name)
+	if err != nil {
+		return nil, err
+	}
+	entry.tmpl = tmpl
+	s.templates[name] = entry
+	return tmpl, nil
+}
+
+// Lookup returns the template with the given name. It returns an error if there is no such template.
+// In development mode, the template is rebuilt before it is returned, and an error is returned if it fails.
+func (s *Set) Lookup(name string) (*Template, error) {
+	return s.lookup(name)
+}
+
+// Rebuild invokes the builder of the named template again and replaces the template with the result.
+func (s *Set) Rebuild(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.templates[name]
+	if !exists {
+		return fmt.Errorf("template %q does not exist", name)
+	}
+	tmpl, err := entry.build(name)
+	if err != nil {
+		return err
+	}
+	entry.tmpl = tmpl
+	return nil
+}
+
+// ExecuteTemplate executes the template with the given name, and writes the output to the specified writer.
+func (s *Set) ExecuteTemplate(wr io.Writer, name string, data *Context) error {
+	tmpl, err := s.lookup(name)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(wr, data)
+}
+
+// Names returns the sorted names of all templates in the set.
+func (s *Set) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := maps.Keys(s.templates)
+	slices.Sort(names)
+	return names
+}
+
+// lookup returns the named template, rebuilding it in development mode.
+func (s *Set) lookup(name string) (*Template, error) {
+	s.mu.RLock()
+	entry, exists := s.templates[name]
+	development := s.development
+	var tmpl *Template
+	if exists {
+		tmpl = entry.tmpl
+	}
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("template %q does not exist", name)
+	}
+	if !development {
+		return tmpl, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the template may have been replaced or removed while we did not hold the lock
+	entry, exists = s.templates[name]
+	if !exists {
+		return nil, fmt.Errorf("template %q does not exist", name)
+	}
+	tmpl, err := entry.build(name)
+	if err != nil {
+		return nil, err
+	}
+	entry.tmpl = tmpl
+	return tmpl, nil
+}
+
+// build creates a new template and fills it using the builder of the entry.
+func (e *setEntry) build(name string) (*Template, error) {
+	tmpl := New(name)
+	root := e.builder(tmpl)
+	if e.compiled {
+		return tmpl.Compile(root)
+	}
+	return tmpl.Parse(root)
+}
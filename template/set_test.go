@@ -0,0 +1,153 @@
+package template_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	. "github.com/tbe/godom"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/template"
+	"github.com/tbe/godom/types"
+)
+
+type SetTestSuite struct {
+	suite.Suite
+
+	buf bytes.Buffer
+	set *template.Set
+}
+
+// TestSetTestSuite initializes the test suite.
+func TestSetTestSuite(t *testing.T) {
+	suite.Run(t, new(SetTestSuite))
+}
+
+func (s *SetTestSuite) SetupTest() {
+	s.buf.Reset()
+	s.set = template.NewSet()
+}
+
+func greeting(t *template.Template) types.Element {
+	return P()(Content("Hello, "), t.Placeholder("name"))
+}
+
+func (s *SetTestSuite) TestRegisterAndExecute() {
+	template.Must(s.set.Register("greeting", greeting))
+
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "greeting", &template.Context{
+		Placeholders: map[string]types.Element{"name": Content("World")},
+	}))
+	s.Equal("<p>Hello, World</p>", s.buf.String())
+}
+
+func (s *SetTestSuite) TestCompiled() {
+	s.set.Compiled(true)
+	template.Must(s.set.Register("greeting", greeting))
+
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "greeting", &template.Context{
+		Placeholders: map[string]types.Element{"name": Content("World")},
+	}))
+	s.Equal("<p>Hello, World</p>", s.buf.String())
+}
+
+func (s *SetTestSuite) TestLookup() {
+	tmpl := template.Must(s.set.Register("greeting", greeting))
+
+	found, err := s.set.Lookup("greeting")
+	s.NoError(err)
+	s.Same(tmpl, found)
+	_, err = s.set.Lookup("missing")
+	s.Error(err)
+	s.Equal([]string{"greeting"}, s.set.Names())
+}
+
+func (s *SetTestSuite) TestMissingTemplate() {
+	s.Error(s.set.ExecuteTemplate(&s.buf, "missing", nil))
+	s.Error(s.set.Rebuild("missing"))
+}
+
+func (s *SetTestSuite) TestDuplicateRegistration() {
+	template.Must(s.set.Register("greeting", greeting))
+
+	_, err := s.set.Register("greeting", greeting)
+	s.Error(err)
+}
+
+func (s *SetTestSuite) TestDevelopmentRebuild() {
+	s.set.Development(true)
+
+	text := "first"
+	builder := func(t *template.Template) types.Element {
+		return P()(Content(text))
+	}
+	template.Must(s.set.Register("text", builder))
+
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "text", nil))
+	s.Equal("<p>first</p>", s.buf.String())
+
+	// every execution rebuilds the template in development mode
+	text = "second"
+	s.buf.Reset()
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "text", nil))
+	s.Equal("<p>second</p>", s.buf.String())
+
+	// and registering again replaces the template
+	template.Must(s.set.Register("text", greeting))
+	s.buf.Reset()
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "text", nil))
+	s.Equal("<p>Hello, </p>", s.buf.String())
+}
+
+func (s *SetTestSuite) TestRebuild() {
+	text := "first"
+	builder := func(t *template.Template) types.Element {
+		return P()(Content(text))
+	}
+	template.Must(s.set.Register("text", builder))
+
+	text = "second"
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "text", nil))
+	s.Equal("<p>first</p>", s.buf.String())
+
+	s.NoError(s.set.Rebuild("text"))
+	s.buf.Reset()
+	s.NoError(s.set.ExecuteTemplate(&s.buf, "text", nil))
+	s.Equal("<p>second</p>", s.buf.String())
+}
+
+func (s *SetTestSuite) TestLookupRebuildError() {
+	s.set.Development(true)
+	fail := false
+	template.Must(s.set.Register("text", func(t *template.Template) types.Element {
+		if fail {
+			return P()(render.Slot(func(io.Writer) error { return errors.New("broken") }))
+		}
+		return P()()
+	}))
+
+	fail = true
+	tmpl, err := s.set.Lookup("text")
+	s.Nil(tmpl)
+	s.ErrorContains(err, "broken")
+}
+
+func (s *SetTestSuite) TestCompiledPerTemplate() {
+	// a parsed template executes template actions in its body, a compiled template writes them as is
+	actions := func(t *template.Template) types.Element {
+		return P()(Content("{{ 1 }}"))
+	}
+	template.Must(s.set.Register("parsed", actions))
+	s.set.Compiled(true)
+	template.Must(s.set.Register("compiled", actions))
+	s.set.Compiled(false)
+
+	for name, expected := range map[string]string{"parsed": "<p>1</p>", "compiled": "<p>{{ 1 }}</p>"} {
+		s.NoError(s.set.Rebuild(name))
+		s.buf.Reset()
+		s.NoError(s.set.ExecuteTemplate(&s.buf, name, nil))
+		s.Equal(expected, s.buf.String(), name)
+	}
+}
@@ -3,6 +3,12 @@ Package template provides a high-level wrapper around the standard html/template
 
 The template package bridges the gap between godom and standard templates, focusing on improving performance and user experience.
 Unlike the upstream html/template package, it currently does not support parsing multiple related templates.
+Instead, templates can be registered by name in a Set, which also supports rebuilding them during development.
+
+Templates can either be parsed into an html/template (Parse), or compiled (Compile). A compiled template splits the
+rendered godom tree into static byte chunks and dynamic slots once, and writes them directly on execution. This avoids
+the reflection and escaping overhead of html/template, but does not support any template actions besides the
+placeholders and attributes defined by this package.
 
 Notably, when using this package, users must provide an access their own data within the `UserData` member of the
 Context, limiting customization.
@@ -18,6 +24,8 @@ import (
 	"fmt"
 	htmltemplate "html/template"
 	"io"
+	"regexp"
+	"strconv"
 
 	"github.com/tbe/godom"
 	"github.com/tbe/godom/helpers"
@@ -25,6 +33,14 @@ import (
 	"github.com/tbe/godom/util"
 )
 
+const (
+	contentAction   = "{{ godoc_content %q .Placeholders }}"
+	attributeAction = "{{ godoc_attribute %q .Attributes }}"
+)
+
+// actionPattern matches the actions created by Template.Placeholder and Template.Attribute in a rendered template.
+var actionPattern = regexp.MustCompile(`\{\{ godoc_(content|attribute) ("(?:[^"\\]|\\.)*") \.(?:Placeholders|Attributes) \}\}`)
+
 // Context represents the data structure used during the template execution.
 // It defines placeholders, attributes, and user data to customize the template rendering.
 type Context struct {
@@ -49,6 +65,19 @@ type Template struct {
 
 	contents   map[string]types.Element
 	attributes map[string]types.Attribute
+
+	// compiled reports whether the template was compiled. A compiled template may render to no chunks at all.
+	compiled bool
+	// chunks holds the chunks of a compiled template.
+	chunks []chunk
+}
+
+// chunk is a part of a compiled template. It consists of static data, followed by an optional dynamic slot.
+type chunk struct {
+	static []byte
+	// slot is either empty, "content" or "attribute"
+	slot string
+	key  string
 }
 
 // New initializes and returns a new Template with the specified name.
@@ -73,6 +102,7 @@ func New(name string) *Template {
 }
 
 // HTML provides direct access to the underlying html/template.Template of the wrapper.
+// The underlying template is not used by compiled templates.
 func (t *Template) HTML() *htmltemplate.Template {
 	return t.html
 }
@@ -85,19 +115,84 @@ func (t *Template) Parse(element types.Element) (*Template, error) {
 	if err != nil {
 		return nil, err
 	}
+	t.compiled, t.chunks = false, nil
 	_, err = t.html.Parse(htmlStr)
 	return t, err
 }
 
+// Compile accepts a godom element and compiles it as the template body.
+// The element is immediately rendered and split into static chunks and dynamic slots for the placeholders and
+// attributes of this template. Executing a compiled template writes these chunks directly, without involving
+// html/template.
+func (t *Template) Compile(element types.Element) (*Template, error) {
+	htmlStr, err := util.RenderToString(element)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []chunk
+	last := 0
+	for _, match := range actionPattern.FindAllStringSubmatchIndex(htmlStr, -1) {
+		key, err := strconv.Unquote(htmlStr[match[4]:match[5]])
+		if err != nil {
+			return nil, fmt.Errorf("invalid template action %q: %w", htmlStr[match[0]:match[1]], err)
+		}
+		chunks = append(chunks, chunk{
+			static: []byte(htmlStr[last:match[0]]),
+			slot:   htmlStr[match[2]:match[3]],
+			key:    key,
+		})
+		last = match[1]
+	}
+	if last < len(htmlStr) {
+		chunks = append(chunks, chunk{static: []byte(htmlStr[last:])})
+	}
+	t.compiled, t.chunks = true, chunks
+
+	return t, nil
+}
+
 // Execute wraps the underlying template's Execute method.
 // It renders the template with the provided context data and writes the output to the specified writer.
+// If the template was compiled, the compiled chunks are written instead.
 func (t *Template) Execute(wr io.Writer, data *Context) error {
 	if data == nil {
 		data = &Context{}
 	}
+	if t.compiled {
+		return t.executeCompiled(wr, data)
+	}
 	return t.html.Execute(wr, data)
 }
 
+// executeCompiled writes the compiled chunks of the template, filling the dynamic slots from the provided context data.
+func (t *Template) executeCompiled(wr io.Writer, data *Context) error {
+	for _, c := range t.chunks {
+		if _, err := wr.Write(c.static); err != nil {
+			return err
+		}
+		switch c.slot {
+		case "content":
+			content, err := t.lookupContent(c.key, data.Placeholders)
+			if err != nil {
+				return err
+			}
+			if err := content.Render(wr); err != nil {
+				return fmt.Errorf("failed to render the contents of %q: %w", c.key, err)
+			}
+		case "attribute":
+			attr, err := t.renderAttribute(c.key, data.Attributes)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(wr, attr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Placeholder defines a placeholder in the template that can later be replaced with actual content.
 // The returned godom element represents the placeholder in template syntax. Initially, it's set to an empty element.
 func (t *Template) Placeholder(key string) types.Element {
@@ -107,7 +202,7 @@ func (t *Template) Placeholder(key string) types.Element {
 	// we set our placeholder to an empty element
 	t.contents[key] = godom.Group()
 
-	return helpers.NewStringElement(fmt.Sprintf("{{ godoc_content %q .Placeholders }}", key))
+	return helpers.NewStringElement(fmt.Sprintf(contentAction, key))
 }
 
 // Attribute defines an attribute placeholder in the template.
@@ -121,7 +216,7 @@ func (t *Template) Attribute(key string) types.Attribute {
 
 	}
 
-	return helpers.FlagAttribute(fmt.Sprintf("{{ godoc_attribute %q .Attributes }}", key))
+	return helpers.FlagAttribute(fmt.Sprintf(attributeAction, key))
 }
 
 // SetFallbackContent specifies the default content for a placeholder.
@@ -146,21 +241,12 @@ func (t *Template) SetFallbackAttribute(key string, attribute types.Attribute) e
 	return nil
 }
 
-// getContent fetches and returns the content associated with a given key.
-// It looks for the content in the provided data and, if not found, falls back to the template's default contents.
+// getContent fetches and returns the rendered content associated with a given key.
+// It is used as a template function, and therefore panics on errors.
 func (t *Template) getContent(key string, data map[string]types.Element) htmltemplate.HTML {
-	var content types.Element
-	exists := false
-
-	if data != nil {
-		// check if we have content for the element
-		content, exists = data[key]
-	}
-	if !exists {
-		content, exists = t.contents[key]
-		if !exists {
-			panic(fmt.Sprintf("missing content for placeholder %q", key))
-		}
+	content, err := t.lookupContent(key, data)
+	if err != nil {
+		panic(err.Error())
 	}
 	rendered, err := util.RenderToString(content)
 	if err != nil {
@@ -169,20 +255,37 @@ func (t *Template) getContent(key string, data map[string]types.Element) htmltem
 	return htmltemplate.HTML(rendered)
 }
 
-// getAttribute fetches and returns the attribute associated with a given key.
-// It looks for the attribute in the provided data and, if not found, falls back to the template's default attributes.
+// getAttribute fetches and returns the rendered attribute associated with a given key.
+// It is used as a template function, and therefore panics on errors.
 func (t *Template) getAttribute(key string, data map[string]types.Attribute) htmltemplate.HTMLAttr {
-	var attr types.Attribute
-	exists := false
+	attr, err := t.renderAttribute(key, data)
+	if err != nil {
+		panic(err.Error())
+	}
+	return htmltemplate.HTMLAttr(attr)
+}
 
-	if data != nil {
-		// check if we have content for the element
-		attr, exists = data[key]
+// lookupContent fetches the content associated with a given key.
+// It looks for the content in the provided data and, if not found, falls back to the template's default contents.
+func (t *Template) lookupContent(key string, data map[string]types.Element) (types.Element, error) {
+	if content, exists := data[key]; exists {
+		return content, nil
 	}
+	content, exists := t.contents[key]
+	if !exists {
+		return nil, fmt.Errorf("missing content for placeholder %q", key)
+	}
+	return content, nil
+}
+
+// renderAttribute fetches the attribute associated with a given key and renders it into a string.
+// It looks for the attribute in the provided data and, if not found, falls back to the template's default attributes.
+func (t *Template) renderAttribute(key string, data map[string]types.Attribute) (string, error) {
+	attr, exists := data[key]
 	if !exists {
 		attr, exists = t.attributes[key]
 		if !exists {
-			panic(fmt.Sprintf("missing content for placeholder %q", key))
+			return "", fmt.Errorf("missing content for placeholder %q", key)
 		}
 	}
 
@@ -196,7 +299,7 @@ func (t *Template) getAttribute(key string, data map[string]types.Attribute) htm
 	// render to a string
 	var allAttrs []string
 	for k, v := range attributes {
-		allAttrs = append(allAttrs, fmt.Sprintf("%s=%q", k, v))
+		allAttrs = append(allAttrs, helpers.FormatAttribute(k, v))
 	}
 	for _, k := range flags {
 		allAttrs = append(allAttrs, k)
@@ -204,12 +307,12 @@ func (t *Template) getAttribute(key string, data map[string]types.Attribute) htm
 
 	// make sure we only have one attribute here
 	if len(allAttrs) > 1 {
-		panic("a template attribute is not allowed to create multiple attributes")
+		return "", fmt.Errorf("a template attribute is not allowed to create multiple attributes")
 	}
 
 	if len(allAttrs) == 1 {
-		return htmltemplate.HTMLAttr(allAttrs[0])
+		return allAttrs[0], nil
 	}
 
-	return ""
+	return "", nil
 }
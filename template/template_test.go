@@ -15,8 +15,9 @@ import (
 type TemplateTestSuite struct {
 	suite.Suite
 
-	buf  bytes.Buffer
-	tmpl *template.Template
+	buf      bytes.Buffer
+	tmpl     *template.Template
+	compiled bool
 }
 
 // TestTemplateTestSuite initializes the test suite.
@@ -24,16 +25,30 @@ func TestTemplateTestSuite(t *testing.T) {
 	suite.Run(t, new(TemplateTestSuite))
 }
 
+// TestCompiledTemplateTestSuite runs the same test suite against compiled templates.
+func TestCompiledTemplateTestSuite(t *testing.T) {
+	suite.Run(t, &TemplateTestSuite{compiled: true})
+}
+
 func (s *TemplateTestSuite) SetupTest() {
 	s.buf.Reset()
 	s.tmpl = template.New("root")
 }
 
+// parse either parses or compiles the root element, depending on the suite mode.
+func (s *TemplateTestSuite) parse(root types.Element) {
+	if s.compiled {
+		template.Must(s.tmpl.Compile(root))
+	} else {
+		template.Must(s.tmpl.Parse(root))
+	}
+}
+
 func (s *TemplateTestSuite) TestTemplateElement() {
 	root := Div()(
 		P()(Content("This is a counter: "), s.tmpl.Placeholder("counter")),
 	)
-	template.Must(s.tmpl.Parse(root))
+	s.parse(root)
 
 	// Check rendering without the counter value
 	s.NoError(s.tmpl.Execute(&s.buf, nil))
@@ -50,7 +65,7 @@ func (s *TemplateTestSuite) TestTemplateElement() {
 
 func (s *TemplateTestSuite) TestTemplateAttribute() {
 	root := Div(s.tmpl.Attribute("class"))()
-	template.Must(s.tmpl.Parse(root))
+	s.parse(root)
 
 	// Check rendering without the class attribute
 	s.NoError(s.tmpl.Execute(&s.buf, nil))
@@ -65,11 +80,20 @@ func (s *TemplateTestSuite) TestTemplateAttribute() {
 	assert.Equal(s.T(), "<div class=\"test\"></div>", s.buf.String())
 }
 
+func (s *TemplateTestSuite) TestTemplateAttributeEscaping() {
+	s.parse(Div(s.tmpl.Attribute("title"))())
+
+	s.NoError(s.tmpl.Execute(&s.buf, &template.Context{
+		Attributes: map[string]types.Attribute{"title": TitleAttr(`say "hi" & <bye>`)},
+	}))
+	s.Equal(`<div title="say &#34;hi&#34; &amp; &lt;bye&gt;"></div>`, s.buf.String())
+}
+
 func (s *TemplateTestSuite) TestMultiplePlaceholders() {
 	root := Div()(
 		P()(s.tmpl.Placeholder("first"), s.tmpl.Placeholder("second")),
 	)
-	template.Must(s.tmpl.Parse(root))
+	s.parse(root)
 
 	// Check rendering with both placeholders
 	firstText := helpers.NewStringElement("Hello")
@@ -86,7 +110,7 @@ func (s *TemplateTestSuite) TestMultiplePlaceholders() {
 
 func (s *TemplateTestSuite) TestMultipleAttributes() {
 	root := Div(s.tmpl.Attribute("class"), s.tmpl.Attribute("id"))()
-	template.Must(s.tmpl.Parse(root))
+	s.parse(root)
 
 	// Check rendering with both attributes
 	testclass := Class("test")
@@ -105,7 +129,7 @@ func (s *TemplateTestSuite) TestFallbackContentAndAttributes() {
 	root := Div()(
 		P()(s.tmpl.Placeholder("placeholder")),
 	)
-	template.Must(s.tmpl.Parse(root))
+	s.parse(root)
 	fallback := helpers.NewStringElement("Fallback Content")
 	s.NoError(s.tmpl.SetFallbackContent("placeholder", fallback))
 
@@ -127,3 +151,76 @@ func (s *TemplateTestSuite) TestDuplicatePlaceholdersPanics() {
 func (s *TemplateTestSuite) TestDuplicateAttributesPanics() {
 	s.Panics(func() { Div(s.tmpl.Attribute("duplicate"), s.tmpl.Attribute("duplicate"))() }, "Expected panic for duplicate attributes")
 }
+
+func (s *TemplateTestSuite) TestCompiledIgnoresTemplateActions() {
+	root := P()(Content("{{ .UserData }}"), s.tmpl.Placeholder("content"))
+	template.Must(s.tmpl.Compile(root))
+
+	s.NoError(s.tmpl.Execute(&s.buf, &template.Context{
+		Placeholders: map[string]types.Element{"content": helpers.NewStringElement(`{{ godoc_content "content" .Placeholders }}`)},
+		UserData:     "ignored",
+	}))
+	s.Equal(`<p>{{ .UserData }}{{ godoc_content "content" .Placeholders }}</p>`, s.buf.String())
+}
+
+func (s *TemplateTestSuite) TestCompiledEmpty() {
+	// an empty compiled template must neither fail as an empty html/template nor execute an earlier parsed body
+	template.Must(s.tmpl.Parse(P()(Content("parsed"))))
+	template.Must(s.tmpl.Compile(Group()))
+
+	s.NoError(s.tmpl.Execute(&s.buf, nil))
+	s.Empty(s.buf.String())
+
+	empty := template.Must(template.New("empty").Compile(Group()))
+	s.NoError(empty.Execute(&s.buf, nil))
+	s.Empty(s.buf.String())
+}
+
+func (s *TemplateTestSuite) TestCompiledMultipleAttributesError() {
+	root := Div(s.tmpl.Attribute("attr"))()
+	template.Must(s.tmpl.Compile(root))
+
+	s.Error(s.tmpl.Execute(&s.buf, &template.Context{
+		Attributes: map[string]types.Attribute{"attr": func(attrs map[string]string, _ *[]string, _ *[]types.Attribute) {
+			attrs["class"] = "a"
+			attrs["id"] = "b"
+		}},
+	}))
+}
+
+func benchmarkExecute(b *testing.B, compiled bool) {
+	tmpl := template.New("bench")
+	root := Div(Class("article"), tmpl.Attribute("data-id"))(
+		H2()(tmpl.Placeholder("title")),
+		P()(tmpl.Placeholder("content")),
+	)
+	if compiled {
+		template.Must(tmpl.Compile(root))
+	} else {
+		template.Must(tmpl.Parse(root))
+	}
+	ctx := &template.Context{
+		Placeholders: map[string]types.Element{
+			"title":   Content("Article Title"),
+			"content": Content("This is the content of the article."),
+		},
+		Attributes: map[string]types.Attribute{"data-id": Data_("id", "12345")},
+	}
+
+	var buf bytes.Buffer
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExecute(b *testing.B) {
+	benchmarkExecute(b, false)
+}
+
+func BenchmarkExecuteCompiled(b *testing.B) {
+	benchmarkExecute(b, true)
+}
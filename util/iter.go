@@ -0,0 +1,50 @@
+//go:build go1.23
+
+package util
+
+import (
+	"io"
+	"iter"
+
+	"github.com/tbe/godom/types"
+)
+
+// MapSeq takes a sequence of data and a creator function that transforms each data item into a godom.Element.
+// Unlike Map, the sequence is only consumed when the returned element is rendered, and every element is rendered
+// as soon as it is created, so the data is never materialized. Single-use sequences can only be rendered once.
+func MapSeq[T any](seq iter.Seq[T], creator func(T) types.Element) types.Element {
+	return &seqElement{each: func(yield func(types.Element) bool) {
+		for v := range seq {
+			if !yield(creator(v)) {
+				return
+			}
+		}
+	}}
+}
+
+// MapSeq2 takes a sequence of pairs, like the entries of a map or the indexed items of a slice, and a creator
+// function that transforms each pair into a godom.Element. See MapSeq.
+func MapSeq2[K, V any](seq iter.Seq2[K, V], creator func(K, V) types.Element) types.Element {
+	return &seqElement{each: func(yield func(types.Element) bool) {
+		for k, v := range seq {
+			if !yield(creator(k, v)) {
+				return
+			}
+		}
+	}}
+}
+
+// seqElement renders the elements of a sequence.
+type seqElement struct {
+	each iter.Seq[types.Element]
+}
+
+func (s *seqElement) Render(writer io.Writer) error {
+	var err error
+	for element := range s.each {
+		if err = element.Render(writer); err != nil {
+			break
+		}
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+//go:build go1.23
+
+package util_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+func TestMapSeq(t *testing.T) {
+	created := 0
+	element := util.MapSeq(slices.Values([]string{"first", "second"}), func(str string) types.Element {
+		created++
+		return godom.P()(godom.Content(str))
+	})
+	// the sequence is only consumed when rendering
+	assert.Zero(t, created)
+
+	var buf bytes.Buffer
+	assert.NoError(t, element.Render(&buf))
+	assert.Equal(t, "<p>first</p><p>second</p>", buf.String())
+	assert.Equal(t, 2, created)
+}
+
+func TestMapSeq2(t *testing.T) {
+	var buf bytes.Buffer
+	seq := maps.All(map[string]string{"a": "1"})
+	assert.NoError(t, util.MapSeq2(seq, func(key, value string) types.Element {
+		return godom.Content(key + "=" + value)
+	}).Render(&buf))
+	assert.Equal(t, "a=1", buf.String())
+}
+
+type failingElement struct{}
+
+func (failingElement) Render(io.Writer) error {
+	return errors.New("failed")
+}
+
+func TestMapSeqStopsOnError(t *testing.T) {
+	created := 0
+	element := util.MapSeq(slices.Values([]int{1, 2, 3}), func(int) types.Element {
+		created++
+		return failingElement{}
+	})
+	assert.Error(t, element.Render(io.Discard))
+	assert.Equal(t, 1, created)
+}
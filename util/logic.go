@@ -1,6 +1,9 @@
 package util
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/tbe/godom"
 	"github.com/tbe/godom/types"
 )
@@ -28,6 +31,54 @@ func IfElem(condition bool, element types.Element) types.Element {
 	}
 }
 
+// IfElse returns one of the given godom.Elements based on the given condition. If the condition is true,
+// the first element is returned; otherwise, the second element is returned.
+func IfElse(condition bool, then, otherwise types.Element) types.Element {
+	if condition {
+		return then
+	}
+	return otherwise
+}
+
+// Clause is a clause of a Switch on a value of type T, created by Case or Default.
+type Clause[T comparable] struct {
+	values   []T
+	element  types.Element
+	fallback bool
+}
+
+// Case returns a Clause of a Switch that matches if the value of the Switch equals one of the given values.
+func Case[T comparable](element types.Element, values ...T) Clause[T] {
+	return Clause[T]{values: values, element: element}
+}
+
+// Default returns a Clause of a Switch that matches if no Case matches.
+func Default[T comparable](element types.Element) Clause[T] {
+	return Clause[T]{element: element, fallback: true}
+}
+
+// Switch returns the godom.Element of the first Case that matches the value. If no Case matches,
+// the element of the Default clause is returned, or an empty element if there is none.
+// The clauses must be of the type of the value, so a Case of another type does not compile.
+//
+//	util.Switch(status,
+//		util.Case(P()(Content("Active")), StatusActive),
+//		util.Case(P()(Content("Inactive")), StatusInactive, StatusDisabled),
+//		util.Default[Status](P()(Content("Unknown"))),
+//	)
+func Switch[T comparable](value T, clauses ...Clause[T]) types.Element {
+	var fallback types.Element = godom.Group()
+	for _, clause := range clauses {
+		switch {
+		case clause.fallback:
+			fallback = clause.element
+		case slices.Contains(clause.values, value):
+			return clause.element
+		}
+	}
+	return fallback
+}
+
 // Map takes a slice of data and a creator function that transforms each data item into
 // a godom.Element. It returns a group of elements created from the data slice.
 func Map[T any](data []T, creator func(T) types.Element) types.Element {
@@ -37,3 +88,29 @@ func Map[T any](data []T, creator func(T) types.Element) types.Element {
 	}
 	return godom.Group(elems...)
 }
+
+// MapIndexed takes a slice of data and a creator function that transforms each data item and its index into
+// a godom.Element. It returns a group of elements created from the data slice.
+func MapIndexed[T any](data []T, creator func(int, T) types.Element) types.Element {
+	elems := make([]types.Element, 0, len(data))
+	for i, e := range data {
+		elems = append(elems, creator(i, e))
+	}
+	return godom.Group(elems...)
+}
+
+// MapMap takes a map and a creator function that transforms each key and value into a godom.Element.
+// It returns a group of elements created from the map, ordered by the keys for a deterministic output.
+func MapMap[K cmp.Ordered, V any](data map[K]V, creator func(K, V) types.Element) types.Element {
+	keys := make([]K, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	elems := make([]types.Element, 0, len(data))
+	for _, k := range keys {
+		elems = append(elems, creator(k, data[k]))
+	}
+	return godom.Group(elems...)
+}
@@ -2,6 +2,7 @@ package util_test
 
 import (
 	"bytes"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,61 @@ func TestMap(t *testing.T) {
 	util.Map(data, factory).Render(&buf)
 	assert.Equal(t, "<p>first</p><p>second</p><p>third</p>", buf.String())
 }
+
+func TestIfElse(t *testing.T) {
+	var buf bytes.Buffer
+	util.IfElse(true, godom.P()(), godom.Span()()).Render(&buf)
+	util.IfElse(false, godom.P()(), godom.Span()()).Render(&buf)
+	assert.Equal(t, "<p></p><span></span>", buf.String())
+}
+
+func TestSwitch(t *testing.T) {
+	status := func(value string) string {
+		var buf bytes.Buffer
+		util.Switch(value,
+			util.Case(godom.Content("Active"), "active"),
+			util.Default[string](godom.Content("Unknown")),
+			util.Case(godom.Content("Inactive"), "inactive", "disabled"),
+		).Render(&buf)
+		return buf.String()
+	}
+	assert.Equal(t, "Active", status("active"))
+	assert.Equal(t, "Inactive", status("disabled"))
+	assert.Equal(t, "Unknown", status("other"))
+
+	{
+		var buf bytes.Buffer
+		util.Switch(1, util.Case(godom.Content("One"), 2)).Render(&buf)
+		assert.Empty(t, buf.String())
+	}
+
+	type state int
+	const (
+		active state = iota
+		inactive
+	)
+	var buf bytes.Buffer
+	util.Switch(inactive,
+		util.Case(godom.Content("Active"), active),
+		util.Case(godom.Content("Inactive"), inactive),
+	).Render(&buf)
+	assert.Equal(t, "Inactive", buf.String())
+}
+
+func TestMapIndexed(t *testing.T) {
+	var buf bytes.Buffer
+	util.MapIndexed([]string{"first", "second"}, func(i int, str string) types.Element {
+		return godom.P(godom.Data_("index", strconv.Itoa(i)))(godom.Content(str))
+	}).Render(&buf)
+	assert.Equal(t, `<p data-index="0">first</p><p data-index="1">second</p>`, buf.String())
+}
+
+func TestMapMap(t *testing.T) {
+	data := map[string]int{"c": 3, "a": 1, "b": 2}
+
+	var buf bytes.Buffer
+	util.MapMap(data, func(key string, value int) types.Element {
+		return godom.Li()(godom.Content(key + "=" + strconv.Itoa(value)))
+	}).Render(&buf)
+	assert.Equal(t, "<li>a=1</li><li>b=2</li><li>c=3</li>", buf.String())
+}
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+type parallelElement struct {
+	workers  int
+	children []types.Element
+}
+
+// parallelResult is the rendered output of a child of a parallelElement.
+type parallelResult struct {
+	done     chan struct{}
+	buf      bytes.Buffer
+	err      error
+	panicked bool
+	panic    any
+}
+
+// Parallel returns a godom.Element that renders its children concurrently, each into its own buffer, and writes
+// the buffers in order, so the output is the same as the output of Group. At most runtime.GOMAXPROCS children
+// are rendered at the same time. This speeds up pages with slow, independent parts, like DelayedElements that
+// query other services:
+//
+//	util.Parallel(
+//		util.DelayedElement(recommendations),
+//		util.DelayedElement(recentComments),
+//	)
+//
+// The children must be safe to render concurrently. Per-render state of render.Document, like the entries of
+// the head package, is collected in the order the children are rendered, which may differ between renders.
+//
+// If a child fails, no further children are started, the context of the running children is canceled and the error
+// is returned. No further children are started either if the context of the render (see render.Context) is done.
+// Children that render for a long time should observe the context themselves.
+func Parallel(children ...types.Element) types.Element {
+	return ParallelN(0, children...)
+}
+
+// ParallelN works like Parallel, but renders at most workers children at the same time.
+// If workers is 0 or less, runtime.GOMAXPROCS is used.
+func ParallelN(workers int, children ...types.Element) types.Element {
+	return &parallelElement{workers: workers, children: children}
+}
+
+func (p *parallelElement) Render(writer io.Writer) error {
+	// the context is canceled on failures, so running siblings that observe it are aborted
+	ctx, cancel := context.WithCancel(render.Context(writer))
+	defer cancel()
+	workers := p.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]parallelResult, len(p.children))
+	for i := range results {
+		results[i].done = make(chan struct{})
+	}
+
+	var stopped atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(1)
+	// the children are started in order, so the first children are written as early as possible
+	go func() {
+		defer wg.Done()
+		sem := make(chan struct{}, workers)
+		for i, child := range p.children {
+			sem <- struct{}{}
+			if stopped.Load() {
+				return
+			}
+			r := &results[i]
+			if err := ctx.Err(); err != nil {
+				r.err = err
+				close(r.done)
+				<-sem
+				continue
+			}
+
+			wg.Add(1)
+			go func(child types.Element) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				defer close(r.done)
+				defer func() {
+					// a panic is raised again by the rendering goroutine, as it would be by a sequential render
+					if v := recover(); v != nil {
+						r.panicked, r.panic = true, v
+					}
+				}()
+				r.err = child.Render(render.WithContext(&r.buf, ctx))
+			}(child)
+		}
+	}()
+
+	for i := range results {
+		r := &results[i]
+		<-r.done
+		if r.panicked {
+			stopped.Store(true)
+			cancel()
+			wg.Wait()
+			panic(r.panic)
+		}
+		if r.err == nil {
+			_, r.err = writer.Write(r.buf.Bytes())
+		}
+		if r.err != nil {
+			stopped.Store(true)
+			cancel()
+			wg.Wait()
+			return r.err
+		}
+		// the output was written, so we release its memory early
+		r.buf = bytes.Buffer{}
+	}
+	wg.Wait()
+	return nil
+}
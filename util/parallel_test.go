@@ -0,0 +1,126 @@
+package util_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+// slowItems returns n delayed list items. Earlier items take longer, so they finish last when rendered concurrently.
+// The number of items rendered at the same time is tracked in active and the maximum in peak.
+func slowItems(n int, active, peak *atomic.Int32) []types.Element {
+	items := make([]types.Element, n)
+	for i := range items {
+		i := i
+		items[i] = util.DelayedElement(func() types.Element {
+			current := active.Add(1)
+			defer active.Add(-1)
+			for {
+				highest := peak.Load()
+				if current <= highest || peak.CompareAndSwap(highest, current) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(n-i) * time.Millisecond)
+			return godom.Li(godom.Class("item"))(godom.Content(strconv.Itoa(i)))
+		})
+	}
+	return items
+}
+
+func TestParallel(t *testing.T) {
+	var active, peak atomic.Int32
+	var sequential, parallel bytes.Buffer
+	assert.NoError(t, godom.UL()(slowItems(8, &active, &peak)...).Render(&sequential))
+	assert.NoError(t, godom.UL()(util.ParallelN(3, slowItems(8, &active, &peak)...)).Render(&parallel))
+
+	assert.Equal(t, sequential.String(), parallel.String())
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Greater(t, peak.Load(), int32(1))
+}
+
+func TestParallelError(t *testing.T) {
+	failure := errors.New("lookup failed")
+	var rendered atomic.Int32
+	children := []types.Element{
+		util.DelayedElement(func() types.Element { rendered.Add(1); return godom.Content("a") }),
+		render.Slot(func(io.Writer) error { return failure }),
+	}
+	for i := 0; i < 10; i++ {
+		children = append(children, util.DelayedElement(func() types.Element {
+			rendered.Add(1)
+			time.Sleep(time.Millisecond)
+			return godom.Content("b")
+		}))
+	}
+
+	var buf bytes.Buffer
+	assert.ErrorIs(t, util.ParallelN(1, children...).Render(&buf), failure)
+	assert.Equal(t, "a", buf.String())
+	assert.Less(t, rendered.Load(), int32(3), "no further children are started")
+}
+
+func TestParallelAbortsSiblings(t *testing.T) {
+	failure := errors.New("lookup failed")
+	started := make(chan struct{})
+	watching := render.Slot(func(w io.Writer) error {
+		close(started)
+		select {
+		case <-render.Context(w).Done():
+			return render.Context(w).Err()
+		case <-time.After(5 * time.Second):
+			return errors.New("sibling was not aborted")
+		}
+	})
+	failing := render.Slot(func(io.Writer) error {
+		<-started
+		return failure
+	})
+
+	begin := time.Now()
+	var buf bytes.Buffer
+	assert.ErrorIs(t, util.ParallelN(2, failing, watching).Render(&buf), failure)
+	assert.Less(t, time.Since(begin), time.Second)
+}
+
+func TestParallelCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	err := util.Parallel(godom.Content("a")).Render(render.WithContext(&buf, ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, buf.String())
+}
+
+func TestParallelPanic(t *testing.T) {
+	element := util.Parallel(util.DelayedElement(func() types.Element { panic("broken") }))
+	assert.PanicsWithValue(t, "broken", func() { _ = element.Render(io.Discard) })
+}
+
+func TestParallelDocument(t *testing.T) {
+	slot := func(text string) types.Element {
+		return render.Slot(func(w io.Writer) error {
+			_, err := io.WriteString(w, text)
+			return err
+		})
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, render.Document(&buf, util.Parallel(
+		godom.Div()(slot("1")),
+		godom.Div()(slot("2"), slot("3")),
+	)))
+	assert.Equal(t, "<div>1</div><div>23</div>", buf.String())
+}
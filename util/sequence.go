@@ -0,0 +1,94 @@
+package util
+
+import (
+	"errors"
+	"io"
+
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+)
+
+// ElementSource returns the next element of a sequence. It returns io.EOF when the sequence is exhausted.
+type ElementSource func() (types.Element, error)
+
+type lazyElement struct {
+	next ElementSource
+}
+
+// Lazy returns a godom.Element that renders the elements returned by next, until it returns io.EOF.
+// Every element is rendered as soon as it is returned, so the memory usage does not depend on the length
+// of the sequence, e.g. when rendering the rows of a database cursor:
+//
+//	util.Lazy(func() (types.Element, error) {
+//		if !rows.Next() {
+//			if err := rows.Err(); err != nil {
+//				return nil, err
+//			}
+//			return nil, io.EOF
+//		}
+//		var name string
+//		if err := rows.Scan(&name); err != nil {
+//			return nil, err
+//		}
+//		return TR()(TD()(Content(name))), nil
+//	})
+//
+// Any other error of next aborts the render and is returned. The render is also aborted if the context of the
+// render (see render.Context) is done. Unlike DelayedElement, the sequence is consumed by the first render.
+func Lazy(next ElementSource) types.Element {
+	return &lazyElement{next: next}
+}
+
+func (l *lazyElement) Render(writer io.Writer) error {
+	ctx := render.Context(writer)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		element, err := l.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := element.Render(writer); err != nil {
+			return err
+		}
+	}
+}
+
+type channelElement struct {
+	elements <-chan types.Element
+	err      func() error
+}
+
+// Channel returns a godom.Element that renders the elements received from the channel, until it is closed.
+// Every element is rendered as soon as it is received. After the channel was closed, err is called to report
+// the error of the producer, if any; err may be nil.
+//
+// The render is aborted if the context of the render (see render.Context) is done. The producer should therefore
+// also watch the context, to not block on a channel that is no longer read.
+func Channel(elements <-chan types.Element, err func() error) types.Element {
+	return &channelElement{elements: elements, err: err}
+}
+
+func (c *channelElement) Render(writer io.Writer) error {
+	ctx := render.Context(writer)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case element, ok := <-c.elements:
+			if !ok {
+				if c.err != nil {
+					return c.err()
+				}
+				return nil
+			}
+			if err := element.Render(writer); err != nil {
+				return err
+			}
+		}
+	}
+}
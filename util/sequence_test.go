@@ -0,0 +1,70 @@
+package util_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tbe/godom"
+	"github.com/tbe/godom/render"
+	"github.com/tbe/godom/types"
+	"github.com/tbe/godom/util"
+)
+
+// rows returns an ElementSource of n table rows.
+func rows(n int, err error) util.ElementSource {
+	i := 0
+	return func() (types.Element, error) {
+		if i == n {
+			if err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		i++
+		return godom.TR()(godom.TD()(godom.Content(strconv.Itoa(i)))), nil
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, godom.Table()(util.Lazy(rows(2, nil))).Render(&buf))
+	assert.Equal(t, "<table><tr><td>1</td></tr><tr><td>2</td></tr></table>", buf.String())
+
+	failure := errors.New("cursor failed")
+	buf.Reset()
+	assert.ErrorIs(t, util.Lazy(rows(1, failure)).Render(&buf), failure)
+	assert.Equal(t, "<tr><td>1</td></tr>", buf.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, util.Lazy(rows(1, nil)).Render(render.WithContext(io.Discard, ctx)), context.Canceled)
+}
+
+func TestChannel(t *testing.T) {
+	produce := func(n int) <-chan types.Element {
+		ch := make(chan types.Element)
+		go func() {
+			defer close(ch)
+			for i := 1; i <= n; i++ {
+				ch <- godom.Li()(godom.Content(strconv.Itoa(i)))
+			}
+		}()
+		return ch
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, godom.UL()(util.Channel(produce(3), nil)).Render(&buf))
+	assert.Equal(t, "<ul><li>1</li><li>2</li><li>3</li></ul>", buf.String())
+
+	failure := errors.New("producer failed")
+	assert.ErrorIs(t, util.Channel(produce(1), func() error { return failure }).Render(io.Discard), failure)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, util.Channel(make(chan types.Element), nil).Render(render.WithContext(io.Discard, ctx)), context.Canceled)
+}